@@ -0,0 +1,8 @@
+data "svix_environment" "production" {
+  name = "Production"
+}
+
+# or look up the environment by id
+data "svix_environment" "staging" {
+  id = "env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo"
+}
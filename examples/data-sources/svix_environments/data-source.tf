@@ -0,0 +1,5 @@
+data "svix_environments" "all" {}
+
+output "environment_ids" {
+  value = [for env in data.svix_environments.all.environments : env.id]
+}
@@ -0,0 +1,8 @@
+data "svix_environment" "production" {
+  name = "Production"
+}
+
+data "svix_event_type" "user_signup" {
+  environment_id = data.svix_environment.production.id
+  name           = "user.signup"
+}
@@ -0,0 +1,13 @@
+data "svix_environment" "production" {
+  name = "Production"
+}
+
+data "svix_event_types" "billing" {
+  environment_id   = data.svix_environment.production.id
+  group_name       = "billing"
+  include_archived = false
+}
+
+output "billing_event_types" {
+  value = [for et in data.svix_event_types.billing.event_types : et.name]
+}
@@ -0,0 +1,12 @@
+data "svix_environment" "production" {
+  name = "Production"
+}
+
+data "svix_ingest_source" "github" {
+  environment_id = data.svix_environment.production.id
+  id             = "src_2yZwUhtgs5Ai8T9yRQJXA"
+}
+
+output "github_ingest_url" {
+  value = data.svix_ingest_source.github.ingest_url
+}
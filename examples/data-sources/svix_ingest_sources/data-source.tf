@@ -0,0 +1,7 @@
+data "svix_environment" "production" {
+  name = "Production"
+}
+
+data "svix_ingest_sources" "all" {
+  environment_id = data.svix_environment.production.id
+}
@@ -0,0 +1,7 @@
+ephemeral "svix_app_portal_access" "example" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_app.id
+  capabilities   = ["ViewBase", "ViewEndpointSecret"]
+  expiry         = 3600
+  read_only      = true
+}
@@ -0,0 +1,15 @@
+ephemeral "svix_endpoint_secret" "example" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_app.id
+  endpoint_id    = svix_endpoint.example_endpoint.id
+}
+
+# store the secret in vault without persisting it in the terraform state
+resource "vault_kv_secret_v2" "endpoint_secret" {
+  mount = "secret"
+  name  = "svix/endpoint-secret"
+  data_json_wo = jsonencode({
+    secret = ephemeral.svix_endpoint_secret.example.secret
+  })
+  data_json_wo_version = 1
+}
@@ -0,0 +1,5 @@
+ephemeral "svix_ingest_endpoint_secret" "example" {
+  environment_id   = svix_environment.example_environment.id
+  ingest_source_id = svix_ingest_source.example_source.id
+  endpoint_id      = svix_ingest_endpoint.example_endpoint.id
+}
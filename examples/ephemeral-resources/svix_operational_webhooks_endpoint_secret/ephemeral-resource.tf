@@ -0,0 +1,4 @@
+ephemeral "svix_operational_webhooks_endpoint_secret" "example" {
+  environment_id = svix_environment.example_environment.id
+  endpoint_id    = svix_operational_webhooks_endpoint.example_endpoint.id
+}
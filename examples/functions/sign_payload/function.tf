@@ -0,0 +1,8 @@
+output "signature" {
+  value = provider::svix::sign_payload(
+    "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+    "msg_p5jXN8AQM9LWM0D4loKWxJek",
+    1614265330,
+    jsonencode({ test = 2432232314 }),
+  )
+}
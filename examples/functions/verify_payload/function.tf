@@ -0,0 +1,19 @@
+locals {
+  msg_id    = "msg_p5jXN8AQM9LWM0D4loKWxJek"
+  timestamp = 1614265330
+  body      = jsonencode({ test = 2432232314 })
+}
+
+# make sure the endpoint secret is a valid `whsec_` key that can sign and verify payloads
+check "endpoint_secret" {
+  assert {
+    condition = provider::svix::verify_payload(
+      svix_endpoint.example_endpoint.secret,
+      local.msg_id,
+      local.timestamp,
+      local.body,
+      provider::svix::sign_payload(svix_endpoint.example_endpoint.secret, local.msg_id, local.timestamp, local.body),
+    )
+    error_message = "The endpoint secret can't be used to verify webhooks"
+  }
+}
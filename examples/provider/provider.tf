@@ -13,5 +13,38 @@ provider "svix" {
   token      = "***"
 }
 
-# The provider can also be configured via the SVIX_TOKEN and SVIX_SERVER_URL environment variables
+# The provider can also be configured via the SVIX_TOKEN and SVIX_SERVER_URL environment variables.
+# Requests sent to the Svix API are logged with TF_LOG_PROVIDER=DEBUG (TRACE also logs the bodies, with secrets redacted)
 provider "svix" {}
+
+# When `server_url` isn't set, the api url of the region is used.
+# The region defaults to the region the token was issued in (e.g. tokens ending with `.eu`)
+provider "svix" {
+  alias  = "us"
+  region = "us"
+}
+
+# Short-lived tokens can be read from a file, or fetched by a command printing
+# `{"status": {"token": "...", "expirationTimestamp": "..."}}`
+provider "svix" {
+  alias         = "ci"
+  token_command = ["./scripts/fetch-svix-token.sh"]
+
+  # environment scoped tokens are used instead of the organization token
+  environment_tokens = {
+    "env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo" = var.staging_token
+  }
+}
+
+# Resources that don't set `environment_id` are created in the provider's default environment,
+# which can also be set with the SVIX_ENVIRONMENT_ID environment variable
+provider "svix" {
+  alias          = "staging"
+  environment_id = "env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo"
+}
+
+resource "svix_event_type" "user_signup" {
+  provider    = svix.staging
+  name        = "user.signup"
+  description = "A user has signed up"
+}
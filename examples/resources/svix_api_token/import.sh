@@ -0,0 +1,3 @@
+# API tokens can be imported using `<environment_id>/<token_id>`
+# NOTE: the token value is only returned on creation, so `token` will be empty after an import
+terraform import svix_api_token.example_token env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/key_2ZcC3Pq9VlTdE3g4pYhWWQ4y0Hc
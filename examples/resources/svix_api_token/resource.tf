@@ -9,3 +9,15 @@ resource "svix_api_token" "example_token" {
   scopes         = ["application:Read"]
 }
 
+# Rotate the token every ~90 days, keeping the old one valid for an hour after it is replaced
+resource "svix_api_token" "rotating_token" {
+  environment_id       = svix_environment.example_environment.id
+  name                 = "Rotating token"
+  expires_in           = "2160h"
+  rotate_before        = "168h"
+  destroy_grace_period = "1h"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,2 @@
+# Applications can be imported using `<environment_id>/<app_id>`
+terraform import svix_application.example_application env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/app_2ZcC3Pq9VlTdE3g4pYhWWQ4y0Hc
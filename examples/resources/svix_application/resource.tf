@@ -0,0 +1,14 @@
+resource "svix_environment" "example_environment" {
+  name = "Staging env"
+  type = "development"
+}
+
+resource "svix_application" "example_application" {
+  environment_id = svix_environment.example_environment.id
+  name           = "Acme Inc."
+  uid            = "acme-inc"
+  rate_limit     = 100
+  metadata = jsonencode({
+    customer_id = "cus_1234"
+  })
+}
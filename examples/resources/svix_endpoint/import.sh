@@ -0,0 +1,2 @@
+# Endpoints can be imported using `<environment_id>/<app_id>/<endpoint_id>`
+terraform import svix_endpoint.example_endpoint env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/app_2ZcC3Pq9VlTdE3g4pYhWWQ4y0Hc/ep_2ZcC5H2YAf8VJkXLUkUdg4KUBpP
@@ -0,0 +1,31 @@
+resource "svix_environment" "example_environment" {
+  name = "Staging env"
+  type = "development"
+}
+
+resource "svix_application" "example_application" {
+  environment_id = svix_environment.example_environment.id
+  name           = "Acme Inc."
+}
+
+resource "svix_endpoint" "example_endpoint" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  url            = "https://example.com/webhooks"
+  description    = "example description"
+  filter_types   = ["invoice.paid", "invoice.failed"]
+  channels       = ["project_123"]
+  rate_limit     = 10
+  metadata = jsonencode({
+    key1 = "foo"
+  })
+  # change to rotate the endpoint's secret, the previous secret stays valid for 24 hours
+  rotation_trigger = {
+    rotated_at = "2025-01-01"
+  }
+}
+
+output "example_endpoint_secrets" {
+  value     = [svix_endpoint.example_endpoint.secret, svix_endpoint.example_endpoint.previous_secret]
+  sensitive = true
+}
@@ -0,0 +1,8 @@
+# Endpoint headers can be imported using `<environment_id>/<app_id>/<endpoint_id>` for an application endpoint
+terraform import svix_endpoint_headers.example_endpoint_headers env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/app_2ZcC3Pq9VlTdE3g4pYhWWQ4y0Hc/ep_2ZcC5H2YAf8VJkXLUkUdg4KUBpP
+
+# `<environment_id>/ingest/<ingest_source_id>/<endpoint_id>` for an ingest endpoint
+terraform import svix_endpoint_headers.example_endpoint_headers env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/ingest/src_2ZcC3Pq9VlTdE3g4pYhWWQ4y0Hc/ep_2ZcC5H2YAf8VJkXLUkUdg4KUBpP
+
+# and `<environment_id>/<endpoint_id>` for an operational webhooks endpoint
+terraform import svix_endpoint_headers.example_op_webhooks_endpoint_headers env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/ep_2ZcC5H2YAf8VJkXLUkUdg4KUBpP
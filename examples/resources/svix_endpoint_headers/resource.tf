@@ -0,0 +1,48 @@
+resource "svix_environment" "example_environment" {
+  name = "Staging env"
+  type = "development"
+}
+
+resource "svix_application" "example_application" {
+  environment_id = svix_environment.example_environment.id
+  name           = "Acme Inc."
+}
+
+resource "svix_endpoint" "example_endpoint" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  url            = "https://example.com/webhooks"
+}
+
+variable "webhooks_api_key" {
+  type      = string
+  sensitive = true
+}
+
+# headers of an application endpoint
+resource "svix_endpoint_headers" "example_endpoint_headers" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  endpoint_id    = svix_endpoint.example_endpoint.id
+  headers = {
+    "X-Example-Tenant" = "acme"
+  }
+  sensitive_headers = {
+    "Authorization" = "Bearer ${var.webhooks_api_key}"
+  }
+}
+
+resource "svix_operational_webhooks_endpoint" "example_op_webhooks_endpoint" {
+  environment_id = svix_environment.example_environment.id
+  url            = "https://example.com/svix-events"
+  filter_types   = ["endpoint.disabled"]
+}
+
+# headers of an operational webhooks endpoint, set `ingest_source_id` instead of `app_id` for an ingest endpoint
+resource "svix_endpoint_headers" "example_op_webhooks_endpoint_headers" {
+  environment_id = svix_environment.example_environment.id
+  endpoint_id    = svix_operational_webhooks_endpoint.example_op_webhooks_endpoint.id
+  sensitive_headers = {
+    "Authorization" = "Bearer ${var.webhooks_api_key}"
+  }
+}
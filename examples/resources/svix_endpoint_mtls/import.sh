@@ -0,0 +1,3 @@
+# The mTLS config of an endpoint can be imported using `<environment_id>/<app_id>/<endpoint_id>`,
+# the certificate and private key are set on the next apply since they can't be read back
+terraform import svix_endpoint_mtls.example_endpoint_mtls env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/app_2ZcC3Pq9VlTdE3g4pYhWWQ4y0Hc/ep_2ZcC5H2YAf8VJkXLUkUdg4KUBpP
@@ -0,0 +1,30 @@
+resource "svix_environment" "example_environment" {
+  name = "Production env"
+  type = "production"
+}
+
+resource "svix_environment_settings" "example_environment_settings" {
+  environment_id              = svix_environment.example_environment.id
+  enable_endpoint_mtls_config = true # Requires Enterprise plan
+}
+
+resource "svix_application" "example_application" {
+  environment_id = svix_environment.example_environment.id
+  name           = "Acme Inc."
+}
+
+resource "svix_endpoint" "example_endpoint" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  url            = "https://example.com/webhooks"
+}
+
+resource "svix_endpoint_mtls" "example_endpoint_mtls" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  endpoint_id    = svix_endpoint.example_endpoint.id
+  certificate    = file("${path.module}/client.crt")
+  private_key    = file("${path.module}/client.key")
+
+  depends_on = [svix_environment_settings.example_environment_settings]
+}
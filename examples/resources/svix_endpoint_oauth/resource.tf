@@ -0,0 +1,39 @@
+resource "svix_environment" "example_environment" {
+  name = "Production env"
+  type = "production"
+}
+
+resource "svix_environment_settings" "example_environment_settings" {
+  environment_id               = svix_environment.example_environment.id
+  enable_endpoint_oauth_config = true # Requires Enterprise plan
+}
+
+resource "svix_application" "example_application" {
+  environment_id = svix_environment.example_environment.id
+  name           = "Acme Inc."
+}
+
+resource "svix_endpoint" "example_endpoint" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  url            = "https://example.com/webhooks"
+}
+
+variable "oauth_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+resource "svix_endpoint_oauth" "example_endpoint_oauth" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  endpoint_id    = svix_endpoint.example_endpoint.id
+  token_url      = "https://auth.example.com/oauth2/token"
+  client_id      = "svix-webhooks"
+  client_secret  = var.oauth_client_secret
+  scopes         = ["webhooks:write"]
+  auth_method    = "clientSecretBasic"
+  grant_type     = "clientCredentials"
+
+  depends_on = [svix_environment_settings.example_environment_settings]
+}
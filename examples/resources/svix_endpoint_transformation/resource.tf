@@ -0,0 +1,30 @@
+resource "svix_environment" "example_environment" {
+  name = "Staging env"
+  type = "development"
+}
+
+resource "svix_environment_settings" "example_environment_settings" {
+  environment_id         = svix_environment.example_environment.id
+  enable_transformations = true
+}
+
+resource "svix_application" "example_application" {
+  environment_id = svix_environment.example_environment.id
+  name           = "Acme Inc."
+}
+
+resource "svix_endpoint" "example_endpoint" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  url            = "https://example.com/webhooks"
+}
+
+resource "svix_endpoint_transformation" "example_endpoint_transformation" {
+  environment_id = svix_environment.example_environment.id
+  app_id         = svix_application.example_application.id
+  endpoint_id    = svix_endpoint.example_endpoint.id
+  code           = file("${path.module}/transformation.js")
+  enabled        = true
+
+  depends_on = [svix_environment_settings.example_environment_settings]
+}
@@ -0,0 +1,15 @@
+/**
+ * @param webhook the webhook object
+ * @param webhook.method destination method. Allowed values: "POST", "PUT"
+ * @param webhook.url current destination address
+ * @param webhook.eventType current webhook Event Type
+ * @param webhook.payload JSON payload
+ * @param webhook.cancel whether to cancel dispatch of the given webhook
+ */
+function handler(webhook) {
+  webhook.payload = {
+    type: webhook.eventType,
+    data: webhook.payload,
+  };
+  return webhook;
+}
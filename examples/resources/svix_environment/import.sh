@@ -0,0 +1,2 @@
+# Environments can be imported using `<environment_id>`
+terraform import svix_environment.example_environment env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo
@@ -0,0 +1,2 @@
+# Environment settings can be imported using `<environment_id>`
+terraform import svix_environment_settings.example_environment_settings env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo
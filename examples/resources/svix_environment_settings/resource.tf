@@ -57,4 +57,14 @@ resource "svix_environment_settings" "example_environment_settings" {
   require_endpoint_event_types          = false
   whitelabel_headers                    = false # Requires Pro or Enterprise plan
   delete_payload_on_successful_delivery = false # Requires Pro or Enterprise plan
+  read_only                             = false
+  enable_application_alerts             = true
+  application_alert_events              = ["endpoint.disabled", "message.attempt.exhausted"]
+  application_alerts_dashboard_url      = "https://www.example.com/dashboard/webhooks"
+  retry_schedule                        = [5, 300, 1800, 7200, 18000, 36000]
+
+  # whitelabel settings removed from this file are reset on the next apply
+  reset_unmanaged_settings = true
+  # restore the settings from before terraform managed them when this resource is destroyed
+  on_destroy = "snapshot_restore"
 }
@@ -0,0 +1,2 @@
+# Event types can be imported using `<environment_id>/<event_type_name>`
+terraform import svix_event_type.example_event_type env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/user.signup
@@ -0,0 +1,2 @@
+# Ingest endpoints can be imported using `<environment_id>/<ingest_source_id>/<endpoint_id>`
+terraform import svix_ingest_endpoint.example_endpoint env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/src_2ZcC3Pq9VlTdE3g4pYhWWQ4y0Hc/ep_2ZcC5H2YAf8VJkXLUkUdg4KUBpP
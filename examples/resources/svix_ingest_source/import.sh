@@ -0,0 +1,3 @@
+# Ingest sources can be imported using `<environment_id>/<ingest_source_id>`
+# NOTE: secrets in `config` are not returned by the API and need to be set in the configuration again
+terraform import svix_ingest_source.example_ingest_source env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/src_2ZcC3Pq9VlTdE3g4pYhWWQ4y0Hc
@@ -0,0 +1,2 @@
+# Operational webhooks endpoints can be imported using `<environment_id>/<endpoint_id>`
+terraform import svix_operational_webhooks_endpoint.example_endpoint env_2ZcBvLwn5Q7H4bQ1aEEmTyNhrNo/ep_2ZcC5H2YAf8VJkXLUkUdg4KUBpP
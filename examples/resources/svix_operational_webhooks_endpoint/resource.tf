@@ -3,6 +3,11 @@ resource "svix_environment" "example_environment" {
   type = "development"
 }
 
+variable "op_webhooks_secret" {
+  type      = string
+  sensitive = true
+}
+
 resource "svix_operational_webhooks_endpoint" "example_endpoint" {
   environment_id = svix_environment.example_environment.id
   url            = "https://example.com"
@@ -13,4 +18,7 @@ resource "svix_operational_webhooks_endpoint" "example_endpoint" {
     key1 = "foo"
     key2 = "bar"
   })
+  # use the secret the receiver already trusts (e.g. when recreating the endpoint), without saving it in the plan
+  secret_wo         = var.op_webhooks_secret
+  secret_wo_version = 1
 }
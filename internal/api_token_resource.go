@@ -3,9 +3,13 @@ package internal
 import (
 	"context"
 	"fmt"
+	"math"
+	"slices"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,23 +23,33 @@ import (
 )
 
 var _ resource.Resource = &ApiTokenResource{}
+var _ resource.ResourceWithImportState = &ApiTokenResource{}
+var _ resource.ResourceWithValidateConfig = &ApiTokenResource{}
+var _ resource.ResourceWithModifyPlan = &ApiTokenResource{}
+
+// The api only accepts an expiry relative to the time of the request (in seconds), so the
+// expiry returned by the api can be slightly off from the one that was requested.
+const apiTokenExpiryTolerance = time.Minute
 
 func NewApiTokenResource() resource.Resource {
 	return &ApiTokenResource{}
 }
 
 type ApiTokenResource struct {
-	state appState
+	state *appState
 }
 
 type ApiTokenResourceModel struct {
-	EnvironmentId types.String      `tfsdk:"environment_id"`
-	Name          types.String      `tfsdk:"name"`
-	Scopes        types.List        `tfsdk:"scopes"`
-	Token         types.String      `tfsdk:"token"`
-	Id            types.String      `tfsdk:"id"`
-	CreatedAt     timetypes.RFC3339 `tfsdk:"created_at"`
-	ExpiresAt     timetypes.RFC3339 `tfsdk:"expires_at"`
+	EnvironmentId      types.String         `tfsdk:"environment_id"`
+	Name               types.String         `tfsdk:"name"`
+	Scopes             types.List           `tfsdk:"scopes"`
+	ExpiresIn          timetypes.GoDuration `tfsdk:"expires_in"`
+	RotateBefore       timetypes.GoDuration `tfsdk:"rotate_before"`
+	DestroyGracePeriod timetypes.GoDuration `tfsdk:"destroy_grace_period"`
+	Token              types.String         `tfsdk:"token"`
+	Id                 types.String         `tfsdk:"id"`
+	CreatedAt          timetypes.RFC3339    `tfsdk:"created_at"`
+	ExpiresAt          timetypes.RFC3339    `tfsdk:"expires_at"`
 }
 
 func (r *ApiTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,7 +60,7 @@ func (r *ApiTokenResource) Configure(ctx context.Context, req resource.Configure
 	if req.ProviderData == nil {
 		return
 	}
-	state, ok := req.ProviderData.(appState)
+	state, ok := req.ProviderData.(*appState)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
@@ -61,10 +75,11 @@ func (r *ApiTokenResource) Schema(ctx context.Context, req resource.SchemaReques
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"environment_id": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: ENV_ID_DESC,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -77,6 +92,37 @@ func (r *ApiTokenResource) Schema(ctx context.Context, req resource.SchemaReques
 					listvalidator.SizeAtLeast(1),
 				},
 			},
+			"expires_in": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  timetypes.GoDurationType{},
+				Description: "How long the token is valid for after it is created, as a Go duration string (e.g. `2160h`). Changing this forces a new token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("expires_at")),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "When the token expires. Can be set to a fixed RFC3339 timestamp instead of using `expires_in`, changing it forces a new token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"rotate_before": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  timetypes.GoDurationType{},
+				Description: "Plan a replacement of the token once it is within this duration of `expires_at` (e.g. `168h`). Meant to be used together with `expires_in`, has no effect on tokens that don't expire or have a fixed `expires_at`.",
+			},
+			"destroy_grace_period": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  timetypes.GoDurationType{},
+				Description: "How long the token stays valid after it is destroyed, so services can switch to its replacement when using `create_before_destroy`. Defaults to expiring the token immediately.",
+			},
 			// non modifiable fields
 			"token": schema.StringAttribute{Sensitive: true, Computed: true, Description: "The api token"},
 			"id":    schema.StringAttribute{Computed: true},
@@ -87,11 +133,94 @@ func (r *ApiTokenResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"expires_at": schema.StringAttribute{Computed: true, CustomType: timetypes.RFC3339Type{}},
 		},
 	}
 }
 
+func (r *ApiTokenResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ApiTokenResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	durations := map[string]timetypes.GoDuration{
+		"expires_in":           data.ExpiresIn,
+		"rotate_before":        data.RotateBefore,
+		"destroy_grace_period": data.DestroyGracePeriod,
+	}
+	for attr, v := range durations {
+		if v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		d, diags := v.ValueGoDuration()
+		if diags.HasError() {
+			// the custom type already reports invalid durations
+			continue
+		}
+		if d < 0 || (attr == "expires_in" && d == 0) {
+			resp.Diagnostics.AddAttributeError(path.Root(attr), "Invalid Duration", fmt.Sprintf("`%s` must be a positive duration, got: %s", attr, v.ValueString()))
+		} else if d.Seconds() > math.MaxInt32 {
+			resp.Diagnostics.AddAttributeError(path.Root(attr), "Invalid Duration", fmt.Sprintf("`%s` can be at most %d seconds, got: %s", attr, math.MaxInt32, v.ValueString()))
+		}
+	}
+}
+
+func (r *ApiTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// nothing to rotate on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var rotateBefore timetypes.GoDuration
+	var expiresAt, configExpiresAt timetypes.RFC3339
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotate_before"), &rotateBefore)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expires_at"), &configExpiresAt)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if rotateBefore.IsNull() || rotateBefore.IsUnknown() || expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return
+	}
+
+	window, diags := rotateBefore.ValueGoDuration()
+	resp.Diagnostics.Append(diags...)
+	expiry, diags := expiresAt.ValueRFC3339Time()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if time.Now().Add(window).Before(expiry) {
+		return
+	}
+	if !configExpiresAt.IsNull() {
+		// a replacement would expire at the same time
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("rotate_before"),
+			"API token can't be rotated",
+			fmt.Sprintf("The api token expires at %s, which is within `rotate_before` (%s). Tokens with a fixed `expires_at` aren't rotated, change `expires_at` to replace it.", expiresAt.ValueString(), rotateBefore.ValueString()),
+		)
+		return
+	}
+
+	// terraform only replaces the resource if an attribute marked as requiring a replacement changes,
+	// the expiry of the replacement is only known once it is created
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), timetypes.NewRFC3339Unknown())...)
+	resp.RequiresReplace.Append(path.Root("expires_at"))
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("rotate_before"),
+		"API token will be rotated",
+		fmt.Sprintf("The api token expires at %s, which is within `rotate_before` (%s). A replacement token will be created.", expiresAt.ValueString(), rotateBefore.ValueString()),
+	)
+}
+
 func (r *ApiTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// load state/plan
 	var data ApiTokenResourceModel
@@ -103,7 +232,7 @@ func (r *ApiTokenResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// create svix client
-	svx, err := r.state.InternalClientWithEnvId(env_id)
+	svx, err := r.state.InternalClientWithEnvId(ctx, env_id)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -131,45 +260,126 @@ func (r *ApiTokenResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// the api doesn't accept an expiry on creation, so it is set right after
+	expiresAt := timetypes.NewRFC3339TimePointerValue(res.ExpiresAt)
+	var expiry *time.Time
+	if !data.ExpiresIn.IsNull() {
+		expiresIn, diags := data.ExpiresIn.ValueGoDuration()
+		resp.Diagnostics.Append(diags...)
+		expiry = ptr(res.CreatedAt.Add(expiresIn))
+	} else if !data.ExpiresAt.IsNull() && !data.ExpiresAt.IsUnknown() {
+		t, diags := data.ExpiresAt.ValueRFC3339Time()
+		resp.Diagnostics.Append(diags...)
+		expiry = &t
+		// keep the configured value, the api only has a precision of seconds
+		expiresAt = data.ExpiresAt
+	}
+	if expiry != nil && !resp.Diagnostics.HasError() {
+		seconds := math.Ceil(time.Until(*expiry).Seconds())
+		if seconds <= 0 || seconds > math.MaxInt32 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expires_at"),
+				"Invalid Expiry",
+				fmt.Sprintf("The api token expiry must be in the future and at most %d seconds from now, got: %s", math.MaxInt32, expiry.Format(time.RFC3339)),
+			)
+		} else {
+			err = svx.Management.Authentication.ExpireApiToken(
+				ctx, env_id, res.Id, models.ApiTokenExpireIn{
+					Expiry: ptr(int32(seconds)),
+				},
+				&internalsvix.ManagementAuthenticationExpireApiTokenOptions{
+					IdempotencyKey: randStr32(),
+				},
+			)
+			if err != nil {
+				logSvixError(&resp.Diagnostics, err, "Unable to set api token expiry")
+			}
+		}
+		if expiresAt.IsNull() {
+			expiresAt = timetypes.NewRFC3339TimeValue(*expiry)
+		}
+	}
+
 	// set the state
 	setCreateState(ctx, resp, rp("environment_id"), env_id)
 	setCreateState(ctx, resp, rp("name"), res.Name)
-	setCreateState(ctx, resp, rp("scopes"), res.Scopes)
+	setCreateState(ctx, resp, rp("scopes"), stringListOrNull(ctx, &resp.Diagnostics, res.Scopes))
 	setCreateState(ctx, resp, rp("token"), res.Token)
 	setCreateState(ctx, resp, rp("id"), res.Id)
 	setCreateState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
-	setCreateState(ctx, resp, rp("expires_at"), timetypes.NewRFC3339TimePointerValue(res.ExpiresAt))
+	setCreateState(ctx, resp, rp("expires_at"), expiresAt)
 }
 
 func (r *ApiTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// TODO(10202) read the actual state of the API token
-	var stateData ApiTokenResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	// load state/plan
+	var env_id, key_id string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &env_id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &key_id)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// call api
+	tokens, err := r.state.listApiTokens(ctx, env_id)
+	if err != nil {
+		logSvixReadError(ctx, resp, err, "Unable to list api tokens")
+		return
+	}
+	idx := slices.IndexFunc(tokens, func(t models.ApiTokenCensoredOut) bool { return t.Id == key_id })
+	if idx == -1 {
+		// the token was deleted outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	res := tokens[idx]
+	if res.ExpiresAt != nil && !res.ExpiresAt.After(time.Now()) {
+		// an expired token can't be used anymore, remove it from the state so it is recreated
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	expiresAt := timetypes.NewRFC3339TimePointerValue(res.ExpiresAt)
+	var prevExpiresAt timetypes.RFC3339
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expires_at"), &prevExpiresAt)...)
+	if res.ExpiresAt != nil && !prevExpiresAt.IsNull() && !prevExpiresAt.IsUnknown() {
+		prev, diags := prevExpiresAt.ValueRFC3339Time()
+		resp.Diagnostics.Append(diags...)
+		if diff := prev.Sub(*res.ExpiresAt).Abs(); diff < apiTokenExpiryTolerance {
+			expiresAt = prevExpiresAt
+		}
+	}
+
 	// set the state
-	setReadState(ctx, resp, rp("environment_id"), stateData.EnvironmentId)
-	setReadState(ctx, resp, rp("name"), stateData.Name)
-	setReadState(ctx, resp, rp("scopes"), stateData.Scopes)
-	setReadState(ctx, resp, rp("token"), stateData.Token)
-	setReadState(ctx, resp, rp("id"), stateData.Id)
-	setReadState(ctx, resp, rp("created_at"), stateData.CreatedAt)
-	setReadState(ctx, resp, rp("expires_at"), stateData.ExpiresAt)
+	setReadState(ctx, resp, rp("environment_id"), env_id)
+	setReadState(ctx, resp, rp("name"), types.StringPointerValue(res.Name))
+	setReadState(ctx, resp, rp("scopes"), stringListOrNull(ctx, &resp.Diagnostics, res.Scopes))
+	setReadState(ctx, resp, rp("id"), res.Id)
+	setReadState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setReadState(ctx, resp, rp("expires_at"), expiresAt)
 }
 
 func (r *ApiTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// load state/plan
 	var env_id, key_id string
+	var gracePeriod timetypes.GoDuration
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &env_id)...)
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &key_id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("destroy_grace_period"), &gracePeriod)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	var expiry int32
+	if !gracePeriod.IsNull() {
+		d, diags := gracePeriod.ValueGoDuration()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		expiry = int32(math.Ceil(d.Seconds()))
+	}
 
 	// create svix client
-	svx, err := r.state.InternalClientWithEnvId(env_id)
+	svx, err := r.state.InternalClientWithEnvId(ctx, env_id)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -178,14 +388,14 @@ func (r *ApiTokenResource) Delete(ctx context.Context, req resource.DeleteReques
 	// call api
 	err = svx.Management.Authentication.ExpireApiToken(
 		ctx, env_id, key_id, models.ApiTokenExpireIn{
-			Expiry: ptr(int32(0)),
+			Expiry: ptr(expiry),
 		},
 		&internalsvix.ManagementAuthenticationExpireApiTokenOptions{
 			IdempotencyKey: randStr32(),
 		},
 	)
 
-	if err != nil {
+	if err != nil && !isNotFoundError(err) {
 		logSvixError(&resp.Diagnostics, err, "Unable to expire api token")
 		return
 	}
@@ -206,7 +416,7 @@ func (r *ApiTokenResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	svx, err := r.state.InternalClientWithEnvId(env_id)
+	svx, err := r.state.InternalClientWithEnvId(ctx, env_id)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -229,6 +439,18 @@ func (r *ApiTokenResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// the token is only returned on creation
+	var token types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("token"), &token)...)
+
 	setUpdateState(ctx, resp, rp("name"), res.Name)
-	setUpdateState(ctx, resp, rp("scopes"), res.Scopes)
+	setUpdateState(ctx, resp, rp("scopes"), stringListOrNull(ctx, &resp.Diagnostics, res.Scopes))
+	setUpdateState(ctx, resp, rp("token"), token)
+	setUpdateState(ctx, resp, rp("id"), res.Id)
+	setUpdateState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	// expires_at can't change without replacing the token, it is kept from the plan
+}
+
+func (r *ApiTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "id")
 }
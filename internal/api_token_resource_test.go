@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// an object of the resource schema with the given attributes, the others are null
+func testObjectValue(t *testing.T, s resource.SchemaResponse, attrs map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+	objType := s.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	vals := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			vals[name] = v
+		} else {
+			vals[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+	return tftypes.NewValue(objType, vals)
+}
+
+func TestApiTokenModifyPlanRotation(t *testing.T) {
+	ctx := context.Background()
+	r := &ApiTokenResource{}
+	var s resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &s)
+
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	soon := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	later := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name            string
+		expiresAt       string
+		configExpiresAt bool
+		replace         bool
+		warning         bool
+	}{
+		{name: "outside the window", expiresAt: later},
+		{name: "inside the window", expiresAt: soon, replace: true, warning: true},
+		{name: "fixed expires_at", expiresAt: soon, configExpiresAt: true, warning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"environment_id": str("env_1"),
+				"name":           str("token"),
+				"rotate_before":  str("168h"),
+			}
+			if tt.configExpiresAt {
+				config["expires_at"] = str(tt.expiresAt)
+			} else {
+				config["expires_in"] = str("720h")
+			}
+			state := map[string]tftypes.Value{
+				"token":      str("testsk_secret"),
+				"id":         str("key_1"),
+				"created_at": str(time.Now().UTC().Format(time.RFC3339)),
+				"expires_at": str(tt.expiresAt),
+			}
+			for k, v := range config {
+				state[k] = v
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s.Schema, Raw: testObjectValue(t, s, config)},
+				State:  tfsdk.State{Schema: s.Schema, Raw: testObjectValue(t, s, state)},
+				Plan:   tfsdk.Plan{Schema: s.Schema, Raw: testObjectValue(t, s, state)},
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			var planExpiresAt timetypes.RFC3339
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("expires_at"), &planExpiresAt)...)
+			if got := resp.RequiresReplace.Contains(path.Root("expires_at")); got != tt.replace {
+				t.Errorf("expires_at requires replace = %v, want %v", got, tt.replace)
+			}
+			// terraform only replaces the resource if the attribute actually changes
+			if tt.replace && !planExpiresAt.IsUnknown() {
+				t.Errorf("planned expires_at = %s, want unknown", planExpiresAt)
+			}
+			if !tt.replace && planExpiresAt.ValueString() != tt.expiresAt {
+				t.Errorf("planned expires_at = %s, want %s", planExpiresAt, tt.expiresAt)
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.warning {
+				t.Errorf("warning = %v, want %v", got, tt.warning)
+			}
+		})
+	}
+}
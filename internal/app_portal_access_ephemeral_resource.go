@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	svix "github.com/svix/svix-webhooks/go"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+var _ ephemeral.EphemeralResource = &AppPortalAccessEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AppPortalAccessEphemeralResource{}
+
+func NewAppPortalAccessEphemeralResource() ephemeral.EphemeralResource {
+	return &AppPortalAccessEphemeralResource{}
+}
+
+type AppPortalAccessEphemeralResource struct {
+	state *appState
+}
+
+type AppPortalAccessEphemeralResourceModel struct {
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	AppId         types.String `tfsdk:"app_id"`
+	Capabilities  types.List   `tfsdk:"capabilities"`
+	Expiry        types.Int64  `tfsdk:"expiry"`
+	FeatureFlags  types.List   `tfsdk:"feature_flags"`
+	ReadOnly      types.Bool   `tfsdk:"read_only"`
+	SessionId     types.String `tfsdk:"session_id"`
+	Url           types.String `tfsdk:"url"`
+	Token         types.String `tfsdk:"token"`
+}
+
+func (r *AppPortalAccessEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "svix_app_portal_access"
+}
+
+func (r *AppPortalAccessEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *AppPortalAccessEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create a magic link to the Consumer Application Portal without storing it in the state",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The Id of the environment the application belongs to, defaults to the provider's `environment_id`",
+			},
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Id or uid of the application",
+			},
+			"capabilities": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Custom capabilities attached to the token, the `ViewBase` capability is always required.\n\n" +
+					"By default, the token will get all capabilities if the capabilities are not explicitly specified.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(
+						string(models.APPPORTALCAPABILITY_VIEW_BASE),
+						string(models.APPPORTALCAPABILITY_VIEW_ENDPOINT_SECRET),
+						string(models.APPPORTALCAPABILITY_MANAGE_ENDPOINT_SECRET),
+						string(models.APPPORTALCAPABILITY_MANAGE_TRANSFORMATIONS),
+						string(models.APPPORTALCAPABILITY_CREATE_ATTEMPTS),
+						string(models.APPPORTALCAPABILITY_MANAGE_ENDPOINT),
+					)),
+				},
+			},
+			"expiry": schema.Int64Attribute{
+				Optional:    true,
+				Description: "How long the token will be valid for, in seconds. Valid values are between 1 hour and 7 days. The default is 7 days.",
+				Validators: []validator.Int64{
+					int64validator.Between(3600, 604800),
+				},
+			},
+			"feature_flags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The set of feature flags the created token will have access to",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the app portal should be in read-only mode",
+			},
+			"session_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "An optional session ID to attach to the token",
+			},
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The magic link to the Consumer Application Portal",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The app portal access token",
+			},
+		},
+	}
+}
+
+func (r *AppPortalAccessEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// load config
+	var data AppPortalAccessEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.EnvironmentId = types.StringValue(r.state.environmentIdOrDefault(ctx, &resp.Diagnostics, data.EnvironmentId))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// create the AppPortalAccessIn struct
+	var capabilities []models.AppPortalCapability
+	var featureFlags []string
+	resp.Diagnostics.Append(data.Capabilities.ElementsAs(ctx, &capabilities, true)...)
+	resp.Diagnostics.Append(data.FeatureFlags.ElementsAs(ctx, &featureFlags, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	accessIn := models.AppPortalAccessIn{
+		Capabilities: capabilities,
+		FeatureFlags: featureFlags,
+		ReadOnly:     boolOrNil(data.ReadOnly),
+		SessionId:    strOrNil(data.SessionId),
+	}
+	if !data.Expiry.IsNull() {
+		accessIn.Expiry = ptr(uint64(data.Expiry.ValueInt64()))
+	}
+
+	// call api
+	res, err := svx.Authentication.AppPortalAccess(
+		ctx, data.AppId.ValueString(), accessIn,
+		&svix.AuthenticationAppPortalAccessOptions{
+			IdempotencyKey: randStr32(),
+		},
+	)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to create app portal access")
+		return
+	}
+
+	// save result
+	data.Url = types.StringValue(res.Url)
+	data.Token = types.StringValue(res.Token)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
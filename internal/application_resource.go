@@ -0,0 +1,329 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	svix "github.com/svix/svix-webhooks/go"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+var _ resource.Resource = &ApplicationResource{}
+var _ resource.ResourceWithImportState = &ApplicationResource{}
+var _ resource.ResourceWithModifyPlan = &ApplicationResource{}
+
+type ApplicationResource struct {
+	state *appState
+}
+
+type ApplicationResourceModel struct {
+	EnvironmentId types.String         `tfsdk:"environment_id"`
+	CreatedAt     timetypes.RFC3339    `tfsdk:"created_at"`
+	Id            types.String         `tfsdk:"id"`
+	Metadata      jsontypes.Normalized `tfsdk:"metadata"`
+	Name          types.String         `tfsdk:"name"`
+	RateLimit     types.Int32          `tfsdk:"rate_limit"`
+	Uid           types.String         `tfsdk:"uid"`
+	UpdatedAt     timetypes.RFC3339    `tfsdk:"updated_at"`
+}
+
+func NewApplicationResource() resource.Resource {
+	return &ApplicationResource{}
+}
+
+func (r *ApplicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *ApplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "svix_application"
+}
+
+func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A consumer application, usually one per customer. Messages are sent to an application and delivered to its endpoints.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: ENV_ID_DESC,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:   true,
+				CustomType: timetypes.RFC3339Type{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				}},
+			"metadata": schema.StringAttribute{
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				Default:             stringdefault.StaticString("{}"),
+				MarkdownDescription: "JSON object encoded as a string, use `jsonencode` to create this field",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Application name for human consumption",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"rate_limit": schema.Int32Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum messages per second to send to this application",
+				Validators: []validator.Int32{
+					// uint16
+					int32validator.AtLeast(1),
+					int32validator.AtMost(65535),
+				}},
+			"uid": schema.StringAttribute{
+				Optional:    true,
+				Description: "Optional unique identifier for the application",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(256),
+					stringvalidator.RegexMatches(saneStringRegex(), "String must match against `^[a-zA-Z0-9\\-_.]+$`"),
+				}},
+			"updated_at": schema.StringAttribute{
+				Computed:   true,
+				CustomType: timetypes.RFC3339Type{},
+			},
+		},
+	}
+}
+
+func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// load state/plan
+	var data ApplicationResourceModel
+	var envId string
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	var appIn models.ApplicationIn
+	{
+		metadata := stringToMapStringT[string](&resp.Diagnostics, data.Metadata.ValueStringPointer())
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var rateLimit *uint16
+		if !data.RateLimit.IsUnknown() && !data.RateLimit.IsNull() {
+			rateLimit = ptr(uint16(data.RateLimit.ValueInt32()))
+		}
+
+		appIn = models.ApplicationIn{
+			Metadata:  metadata,
+			Name:      data.Name.ValueString(),
+			RateLimit: rateLimit,
+			Uid:       strOrNil(data.Uid),
+		}
+	}
+
+	// call api
+	res, err := svx.Application.Create(ctx, appIn, &svix.ApplicationCreateOptions{IdempotencyKey: randStr32()})
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to create application")
+		return
+	}
+
+	// save state
+	metadataOut, err := json.Marshal(res.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Unable to marshal metadata to a string", err.Error())
+	}
+	var rateLimitOut *int32
+	if res.RateLimit != nil {
+		rateLimitOut = ptr(int32(*res.RateLimit))
+	}
+
+	setCreateState(ctx, resp, rp("environment_id"), envId)
+	setCreateState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setCreateState(ctx, resp, rp("id"), types.StringValue(res.Id))
+	setCreateState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
+	setCreateState(ctx, resp, rp("name"), types.StringValue(res.Name))
+	setCreateState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
+	setCreateState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
+	setCreateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
+}
+
+func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// load state/plan
+	var envId, appId string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &appId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	res, err := svx.Application.Get(ctx, appId)
+	if err != nil {
+		logSvixReadError(ctx, resp, err, "Failed to read application")
+		return
+	}
+
+	// save state
+	metadataOut, err := json.Marshal(res.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Unable to marshal metadata to a string", err.Error())
+	}
+	var rateLimitOut *int32
+	if res.RateLimit != nil {
+		rateLimitOut = ptr(int32(*res.RateLimit))
+	}
+
+	setReadState(ctx, resp, rp("environment_id"), envId)
+	setReadState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setReadState(ctx, resp, rp("id"), types.StringValue(res.Id))
+	setReadState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
+	setReadState(ctx, resp, rp("name"), types.StringValue(res.Name))
+	setReadState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
+	setReadState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
+	setReadState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
+}
+
+func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// load state/plan
+	var data ApplicationResourceModel
+	var envId, appId string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &appId)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	var appIn models.ApplicationIn
+	{
+		metadata := stringToMapStringT[string](&resp.Diagnostics, data.Metadata.ValueStringPointer())
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var rateLimit *uint16
+		if !data.RateLimit.IsUnknown() && !data.RateLimit.IsNull() {
+			rateLimit = ptr(uint16(data.RateLimit.ValueInt32()))
+		}
+
+		appIn = models.ApplicationIn{
+			Metadata:  metadata,
+			Name:      data.Name.ValueString(),
+			RateLimit: rateLimit,
+			Uid:       strOrNil(data.Uid),
+		}
+	}
+
+	// call api
+	res, err := svx.Application.Update(ctx, appId, appIn)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to update application")
+		return
+	}
+
+	// save state
+	metadataOut, err := json.Marshal(res.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Unable to marshal metadata to a string", err.Error())
+	}
+	var rateLimitOut *int32
+	if res.RateLimit != nil {
+		rateLimitOut = ptr(int32(*res.RateLimit))
+	}
+
+	setUpdateState(ctx, resp, rp("environment_id"), envId)
+	setUpdateState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setUpdateState(ctx, resp, rp("id"), types.StringValue(res.Id))
+	setUpdateState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
+	setUpdateState(ctx, resp, rp("name"), types.StringValue(res.Name))
+	setUpdateState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
+	setUpdateState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
+	setUpdateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
+}
+
+func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// load state/plan
+	var envId, appId string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &appId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	err = svx.Application.Delete(ctx, appId)
+	if err != nil && !isNotFoundError(err) {
+		logSvixError(&resp.Diagnostics, err, "Failed to delete application")
+		return
+	}
+}
+
+func (r *ApplicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+}
+
+func (r *ApplicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "id")
+}
@@ -1,7 +1,7 @@
 package internal
 
 const (
-	ENV_ID_DESC                     = "The Id to the environment that this resource will be created in"
+	ENV_ID_DESC                     = "The Id to the environment that this resource will be created in, defaults to the provider's `environment_id`"
 	UNABLE_TO_CREATE_SVIX_CLIENT    = "Unable to create svix client, this error should not happen, please contact the developers"
 	REQUIRES_PRO_OR_ENTERPRISE_PLAN = "<strong>Requires Pro or Enterprise plan</strong>, "
 	REQUIRES_ENTERPRISE_PLAN        = "<strong>Requires Enterprise plan</strong>, "
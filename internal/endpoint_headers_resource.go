@@ -0,0 +1,382 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	svix "github.com/svix/svix-webhooks/go"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+var _ resource.Resource = &EndpointHeadersResource{}
+var _ resource.ResourceWithImportState = &EndpointHeadersResource{}
+var _ resource.ResourceWithValidateConfig = &EndpointHeadersResource{}
+var _ resource.ResourceWithModifyPlan = &EndpointHeadersResource{}
+
+// a `token` as defined by RFC 9110, which header names must be
+var httpHeaderNameRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
+func NewEndpointHeadersResource() resource.Resource {
+	return &EndpointHeadersResource{}
+}
+
+type EndpointHeadersResource struct {
+	state *appState
+}
+
+type EndpointHeadersResourceModel struct {
+	EnvironmentId    types.String `tfsdk:"environment_id"`
+	AppId            types.String `tfsdk:"app_id"`
+	IngestSourceId   types.String `tfsdk:"ingest_source_id"`
+	EndpointId       types.String `tfsdk:"endpoint_id"`
+	Headers          types.Map    `tfsdk:"headers"`
+	SensitiveHeaders types.Map    `tfsdk:"sensitive_headers"`
+}
+
+// headers of an application, ingest or operational webhooks endpoint
+type endpointHeaders struct {
+	Headers map[string]string
+	// names of the headers whose values the api doesn't return
+	Sensitive []string
+}
+
+func (r *EndpointHeadersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "svix_endpoint_headers"
+}
+
+func (r *EndpointHeadersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	headerNameValidator := stringvalidator.RegexMatches(httpHeaderNameRegex, "Must be a valid HTTP header name")
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Custom headers sent with every message delivered to an endpoint.\n\n" +
+			"Set `app_id` for an application endpoint (`svix_endpoint`), `ingest_source_id` for an ingest endpoint (`svix_ingest_endpoint`), " +
+			"or neither for an operational webhooks endpoint (`svix_operational_webhooks_endpoint`).\n\n" +
+			"Svix doesn't return the values of sensitive headers (e.g. `Authorization`), so only their names are compared with the API, " +
+			"their values are kept from the configuration.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: ENV_ID_DESC,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The application of the endpoint, for an application endpoint",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ingest_source_id")),
+				},
+			},
+			"ingest_source_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ingest source of the endpoint, for an ingest endpoint",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Headers whose values are shown in the plan",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(headerNameValidator),
+				},
+			},
+			"sensitive_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				MarkdownDescription: "Headers whose values are hidden in the plan, such as authentication headers.\n\n" +
+					"Changes made to the values of these headers outside of terraform are not detected.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(headerNameValidator),
+				},
+			},
+		},
+	}
+}
+
+func (r *EndpointHeadersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *EndpointHeadersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// load state/plan
+	var data EndpointHeadersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	headers := data.allHeaders(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	err = data.updateHeaders(ctx, svx, headers)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to set endpoint headers")
+		return
+	}
+
+	// save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EndpointHeadersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// load state/plan
+	var data EndpointHeadersResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var prevHeaders, prevSensitive map[string]string
+	resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &prevHeaders, false)...)
+	resp.Diagnostics.Append(data.SensitiveHeaders.ElementsAs(ctx, &prevSensitive, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	res, err := data.getHeaders(ctx, svx)
+	if err != nil {
+		logSvixReadError(ctx, resp, err, "Failed to read endpoint headers")
+		return
+	}
+
+	// save state
+	headers, sensitive := splitEndpointHeaders(res, prevHeaders, prevSensitive)
+	setReadState(ctx, resp, rp("headers"), headersMapValue(ctx, &resp.Diagnostics, headers, data.Headers))
+	setReadState(ctx, resp, rp("sensitive_headers"), headersMapValue(ctx, &resp.Diagnostics, sensitive, data.SensitiveHeaders))
+}
+
+func (r *EndpointHeadersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// load state/plan
+	var data EndpointHeadersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	headers := data.allHeaders(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	err = data.updateHeaders(ctx, svx, headers)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to update endpoint headers")
+		return
+	}
+
+	// save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EndpointHeadersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// load state/plan
+	var data EndpointHeadersResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	// headers can't be deleted, remove all of them instead. If the endpoint was deleted, so were its headers
+	err = data.updateHeaders(ctx, svx, map[string]string{})
+	if err != nil && !isNotFoundError(err) {
+		logSvixError(&resp.Diagnostics, err, "Failed to remove endpoint headers")
+	}
+}
+
+func (r *EndpointHeadersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data EndpointHeadersResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Headers.IsUnknown() || data.SensitiveHeaders.IsUnknown() {
+		return
+	}
+
+	// header names are case insensitive, a header can't be both sensitive and not sensitive
+	for name := range data.Headers.Elements() {
+		for sensitiveName := range data.SensitiveHeaders.Elements() {
+			if strings.EqualFold(name, sensitiveName) {
+				resp.Diagnostics.AddAttributeError(
+					rp("sensitive_headers"),
+					"Duplicate Header",
+					fmt.Sprintf("The %q header is set in both `headers` and `sensitive_headers`", name),
+				)
+			}
+		}
+	}
+}
+
+func (r *EndpointHeadersResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+}
+
+// import from `<environment_id>/<endpoint_id>` for an operational webhooks endpoint,
+// `<environment_id>/<app_id>/<endpoint_id>` for an application endpoint
+// and `<environment_id>/ingest/<ingest_source_id>/<endpoint_id>` for an ingest endpoint
+func (r *EndpointHeadersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	switch {
+	case len(parts) == 2:
+		importStateFromId(ctx, req, resp, "environment_id", "endpoint_id")
+	case len(parts) == 3:
+		importStateFromId(ctx, req, resp, "environment_id", "app_id", "endpoint_id")
+	case len(parts) == 4 && parts[1] == "ingest":
+		req.ID = strings.Join([]string{parts[0], parts[2], parts[3]}, "/")
+		importStateFromId(ctx, req, resp, "environment_id", "ingest_source_id", "endpoint_id")
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: `environment_id/endpoint_id`, `environment_id/app_id/endpoint_id` or `environment_id/ingest/ingest_source_id/endpoint_id`. Got: %q", req.ID),
+		)
+	}
+}
+
+// all the headers sent to the endpoint, sensitive or not
+func (m *EndpointHeadersResourceModel) allHeaders(ctx context.Context, d *diag.Diagnostics) map[string]string {
+	headers := map[string]string{}
+	var sensitive map[string]string
+	d.Append(m.Headers.ElementsAs(ctx, &headers, false)...)
+	d.Append(m.SensitiveHeaders.ElementsAs(ctx, &sensitive, false)...)
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	maps.Copy(headers, sensitive)
+	return headers
+}
+
+func (m *EndpointHeadersResourceModel) getHeaders(ctx context.Context, svx *svix.Svix) (*endpointHeaders, error) {
+	endpointId := m.EndpointId.ValueString()
+	switch {
+	case !m.AppId.IsNull():
+		res, err := svx.Endpoint.GetHeaders(ctx, m.AppId.ValueString(), endpointId)
+		if err != nil {
+			return nil, err
+		}
+		return &endpointHeaders{Headers: res.Headers, Sensitive: res.Sensitive}, nil
+	case !m.IngestSourceId.IsNull():
+		res, err := svx.Ingest.Endpoint.GetHeaders(ctx, m.IngestSourceId.ValueString(), endpointId)
+		if err != nil {
+			return nil, err
+		}
+		return &endpointHeaders{Headers: res.Headers, Sensitive: res.Sensitive}, nil
+	default:
+		res, err := svx.OperationalWebhook.Endpoint.GetHeaders(ctx, endpointId)
+		if err != nil {
+			return nil, err
+		}
+		return &endpointHeaders{Headers: res.Headers, Sensitive: res.Sensitive}, nil
+	}
+}
+
+// replace all the headers of the endpoint
+func (m *EndpointHeadersResourceModel) updateHeaders(ctx context.Context, svx *svix.Svix, headers map[string]string) error {
+	endpointId := m.EndpointId.ValueString()
+	switch {
+	case !m.AppId.IsNull():
+		return svx.Endpoint.UpdateHeaders(ctx, m.AppId.ValueString(), endpointId, models.EndpointHeadersIn{Headers: headers})
+	case !m.IngestSourceId.IsNull():
+		return svx.Ingest.Endpoint.UpdateHeaders(ctx, m.IngestSourceId.ValueString(), endpointId, models.IngestEndpointHeadersIn{Headers: headers})
+	default:
+		return svx.OperationalWebhook.Endpoint.UpdateHeaders(ctx, endpointId, models.OperationalWebhookEndpointHeadersIn{Headers: headers})
+	}
+}
+
+// split the headers returned by the api between `headers` and `sensitive_headers`, keeping each header where it was before.
+//
+// The api only returns the names of sensitive headers, so their values are taken from the previous state.
+// A sensitive header that was added outside of terraform gets an empty value, so it is still shown in the diff
+func splitEndpointHeaders(res *endpointHeaders, prevHeaders, prevSensitive map[string]string) (map[string]string, map[string]string) {
+	headers := map[string]string{}
+	sensitive := map[string]string{}
+	for name, value := range res.Headers {
+		if _, ok := prevSensitive[name]; ok {
+			sensitive[name] = value
+		} else {
+			headers[name] = value
+		}
+	}
+	for _, name := range res.Sensitive {
+		if value, ok := prevHeaders[name]; ok {
+			headers[name] = value
+		} else {
+			sensitive[name] = prevSensitive[name]
+		}
+	}
+	return headers, sensitive
+}
+
+// keep a null map null if the endpoint has no headers, so unset attributes don't show a diff
+func headersMapValue(ctx context.Context, d *diag.Diagnostics, headers map[string]string, prev types.Map) types.Map {
+	if len(headers) == 0 && prev.IsNull() {
+		return types.MapNull(types.StringType)
+	}
+	out, diags := types.MapValueFrom(ctx, types.StringType, headers)
+	d.Append(diags...)
+	return out
+}
@@ -0,0 +1,257 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &EndpointMtlsResource{}
+var _ resource.ResourceWithImportState = &EndpointMtlsResource{}
+var _ resource.ResourceWithValidateConfig = &EndpointMtlsResource{}
+var _ resource.ResourceWithModifyPlan = &EndpointMtlsResource{}
+
+// a warning is shown during the plan when the client certificate expires within this duration
+const mtlsCertificateExpiryWarning = 30 * 24 * time.Hour
+
+func NewEndpointMtlsResource() resource.Resource {
+	return &EndpointMtlsResource{}
+}
+
+type EndpointMtlsResource struct {
+	state *appState
+}
+
+type EndpointMtlsResourceModel struct {
+	EnvironmentId        types.String      `tfsdk:"environment_id"`
+	AppId                types.String      `tfsdk:"app_id"`
+	EndpointId           types.String      `tfsdk:"endpoint_id"`
+	Certificate          types.String      `tfsdk:"certificate"`
+	PrivateKey           types.String      `tfsdk:"private_key"`
+	CertificateExpiresAt timetypes.RFC3339 `tfsdk:"certificate_expires_at"`
+}
+
+func (r *EndpointMtlsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "svix_endpoint_mtls"
+}
+
+func (r *EndpointMtlsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: REQUIRES_ENTERPRISE_PLAN + "the client certificate an application endpoint (`svix_endpoint`) is called with, for endpoints that require mutual TLS.\n\n" +
+			"Endpoint mTLS must be enabled with `enable_endpoint_mtls_config` in `svix_environment_settings`. " +
+			"The certificate and private key are checked during the plan, and a warning is shown when the certificate expires within 30 days.\n\n" +
+			"Ingest endpoints (`svix_ingest_endpoint`) aren't supported, the Svix API has no mTLS config for them.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: ENV_ID_DESC,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "PEM encoded X.509 client certificate, followed by its intermediate certificates if any",
+			},
+			"private_key": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "PEM encoded private key of the certificate",
+			},
+			"certificate_expires_at": schema.StringAttribute{
+				Computed:   true,
+				CustomType: timetypes.RFC3339Type{},
+			},
+		},
+	}
+}
+
+func (r *EndpointMtlsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *EndpointMtlsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// load state/plan
+	var data EndpointMtlsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// call api
+	err := r.state.updateEndpointMtls(ctx, data.EnvironmentId.ValueString(), data.AppId.ValueString(), data.EndpointId.ValueString(), data.configIn())
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to set endpoint mTLS config")
+		return
+	}
+
+	// save state
+	data.CertificateExpiresAt = mtlsCertificateExpiresAt(data.Certificate.ValueString(), data.PrivateKey.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EndpointMtlsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// load state/plan
+	var data EndpointMtlsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// call api
+	// the certificate and key can't be read back, only whether they are still set
+	_, err := r.state.getEndpointMtls(ctx, data.EnvironmentId.ValueString(), data.AppId.ValueString(), data.EndpointId.ValueString())
+	if err != nil {
+		logSvixReadError(ctx, resp, err, "Failed to read endpoint mTLS config")
+	}
+}
+
+func (r *EndpointMtlsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// load state/plan
+	var data EndpointMtlsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// call api
+	err := r.state.updateEndpointMtls(ctx, data.EnvironmentId.ValueString(), data.AppId.ValueString(), data.EndpointId.ValueString(), data.configIn())
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to update endpoint mTLS config")
+		return
+	}
+
+	// save state
+	data.CertificateExpiresAt = mtlsCertificateExpiresAt(data.Certificate.ValueString(), data.PrivateKey.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EndpointMtlsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// load state/plan
+	var data EndpointMtlsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// call api
+	err := r.state.deleteEndpointMtls(ctx, data.EnvironmentId.ValueString(), data.AppId.ValueString(), data.EndpointId.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		logSvixError(&resp.Diagnostics, err, "Failed to delete endpoint mTLS config")
+	}
+}
+
+func (r *EndpointMtlsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data EndpointMtlsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Certificate.IsNull() || data.Certificate.IsUnknown() || data.PrivateKey.IsNull() || data.PrivateKey.IsUnknown() {
+		return
+	}
+
+	cert, err := parseMtlsCertificate(data.Certificate.ValueString(), data.PrivateKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate"), "Invalid Client Certificate", err.Error())
+		return
+	}
+
+	now := time.Now()
+	if now.After(cert.NotAfter) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("certificate"),
+			"Client Certificate Expired",
+			fmt.Sprintf("The certificate of %q expired on %s, endpoints that require mTLS will reject the deliveries.", cert.Subject, cert.NotAfter.Format(time.RFC3339)),
+		)
+	} else if cert.NotAfter.Sub(now) < mtlsCertificateExpiryWarning {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("certificate"),
+			"Client Certificate Expires Soon",
+			fmt.Sprintf("The certificate of %q expires on %s, renew it before then to avoid failed deliveries.", cert.Subject, cert.NotAfter.Format(time.RFC3339)),
+		)
+	}
+}
+
+func (r *EndpointMtlsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// the expiry is known as soon as the certificate is
+	var certificate, privateKey types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, rp("certificate"), &certificate)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, rp("private_key"), &privateKey)...)
+	if resp.Diagnostics.HasError() || certificate.IsUnknown() || privateKey.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("certificate_expires_at"), mtlsCertificateExpiresAt(certificate.ValueString(), privateKey.ValueString()))...)
+}
+
+func (r *EndpointMtlsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "app_id", "endpoint_id")
+}
+
+func (m *EndpointMtlsResourceModel) configIn() endpointMtlsConfigIn {
+	return endpointMtlsConfigIn{
+		Identity: strings.TrimSpace(m.PrivateKey.ValueString()) + "\n" + strings.TrimSpace(m.Certificate.ValueString()) + "\n",
+	}
+}
+
+// parse the client certificate and make sure it matches the private key
+func parseMtlsCertificate(certificate, privateKey string) (*x509.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the certificate and private key: %w", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// expiry of the client certificate, null if it can't be parsed (which is reported by ValidateConfig)
+func mtlsCertificateExpiresAt(certificate, privateKey string) timetypes.RFC3339 {
+	cert, err := parseMtlsCertificate(certificate, privateKey)
+	if err != nil {
+		return timetypes.NewRFC3339Null()
+	}
+	return timetypes.NewRFC3339TimeValue(cert.NotAfter.UTC())
+}
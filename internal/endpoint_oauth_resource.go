@@ -0,0 +1,380 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ resource.Resource = &EndpointOauthResource{}
+var _ resource.ResourceWithImportState = &EndpointOauthResource{}
+var _ resource.ResourceWithValidateConfig = &EndpointOauthResource{}
+var _ resource.ResourceWithModifyPlan = &EndpointOauthResource{}
+
+const (
+	oauthAuthMethodClientSecretJwt    = "clientSecretJwt"
+	oauthAuthMethodClientSecretBasic  = "clientSecretBasic"
+	oauthAuthMethodClientSecretPost   = "clientSecretPost"
+	oauthGrantTypeClientCredentials   = "clientCredentials"
+	oauthGrantTypeRefreshToken        = "refreshToken"
+	oauthJwsSigningAlgorithmRS256     = "RS256"
+	oauthJwtDefaultTokenExpirySeconds = 300
+)
+
+var httpUrlRegex = regexp.MustCompile(`^https?://\S+$`)
+
+func NewEndpointOauthResource() resource.Resource {
+	return &EndpointOauthResource{}
+}
+
+type EndpointOauthResource struct {
+	state *appState
+}
+
+type EndpointOauthResourceModel struct {
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	AppId         types.String `tfsdk:"app_id"`
+	EndpointId    types.String `tfsdk:"endpoint_id"`
+	TokenUrl      types.String `tfsdk:"token_url"`
+	ClientId      types.String `tfsdk:"client_id"`
+	ClientSecret  types.String `tfsdk:"client_secret"`
+	Scopes        types.List   `tfsdk:"scopes"`
+	AuthMethod    types.String `tfsdk:"auth_method"`
+	GrantType     types.String `tfsdk:"grant_type"`
+	RefreshToken  types.String `tfsdk:"refresh_token"`
+	ExtraHeaders  types.Map    `tfsdk:"extra_headers"`
+	ExtraParams   types.Map    `tfsdk:"extra_params"`
+	JwtParams     types.Object `tfsdk:"jwt_params"`
+}
+
+type EndpointOauthJwtParamsModel struct {
+	SecretBase64     types.String `tfsdk:"secret_base64"`
+	SecretId         types.String `tfsdk:"secret_id"`
+	SigningAlgorithm types.String `tfsdk:"signing_algorithm"`
+	TokenExpirySecs  types.Int64  `tfsdk:"token_expiry_secs"`
+}
+
+func (r *EndpointOauthResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "svix_endpoint_oauth"
+}
+
+func (r *EndpointOauthResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: REQUIRES_ENTERPRISE_PLAN + "the OAuth client an application endpoint (`svix_endpoint`) is called with. " +
+			"Svix requests an access token from the authorization server and sends it with every delivery to the endpoint.\n\n" +
+			"Endpoint OAuth must be enabled with `enable_endpoint_oauth_config` in `svix_environment_settings`. " +
+			"Svix doesn't return the secrets, so changes made to them outside of terraform are not detected.\n\n" +
+			"Ingest endpoints (`svix_ingest_endpoint`) aren't supported, the Svix API has no OAuth config for them.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: ENV_ID_DESC,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"token_url": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The URL of the authorization server's token endpoint",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpUrlRegex, "Must be an http(s) URL"),
+				},
+			},
+			"client_id": schema.StringAttribute{Required: true},
+			"client_secret": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Required for the `clientSecretBasic` and `clientSecretPost` auth methods",
+			},
+			"scopes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Scopes requested with the access token",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"auth_method": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(oauthAuthMethodClientSecretBasic),
+				MarkdownDescription: "How Svix authenticates to the authorization server: `clientSecretBasic` (the client secret in the `Authorization` header), " +
+					"`clientSecretPost` (the client secret in the request body) or `clientSecretJwt` (a JWT signed with `jwt_params`)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(oauthAuthMethodClientSecretJwt, oauthAuthMethodClientSecretBasic, oauthAuthMethodClientSecretPost),
+				},
+			},
+			"grant_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(oauthGrantTypeClientCredentials),
+				MarkdownDescription: "`clientCredentials` or `refreshToken`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(oauthGrantTypeClientCredentials, oauthGrantTypeRefreshToken),
+				},
+			},
+			"refresh_token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Required for the `refreshToken` grant type",
+			},
+			"extra_headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Headers added to the token request",
+			},
+			"extra_params": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Parameters added to the body of the token request",
+			},
+			"jwt_params": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Required for the `clientSecretJwt` auth method",
+				Attributes: map[string]schema.Attribute{
+					"secret_base64": schema.StringAttribute{
+						Required:            true,
+						Sensitive:           true,
+						MarkdownDescription: "Base64 encoded secret the JWT is signed with",
+					},
+					"secret_id": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Sent in the `kid` header of the JWT",
+					},
+					"signing_algorithm": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(oauthJwsSigningAlgorithmRS256),
+						Validators: []validator.String{
+							stringvalidator.OneOf(oauthJwsSigningAlgorithmRS256),
+						},
+					},
+					"token_expiry_secs": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: fmt.Sprintf("How long the JWT is valid for, defaults to %d seconds", oauthJwtDefaultTokenExpirySeconds),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *EndpointOauthResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *EndpointOauthResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// load state/plan
+	var data EndpointOauthResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configIn := data.configIn(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// call api
+	err := r.state.updateEndpointOauth(ctx, data.EnvironmentId.ValueString(), data.AppId.ValueString(), data.EndpointId.ValueString(), configIn)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to set endpoint OAuth config")
+		return
+	}
+
+	// save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EndpointOauthResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// load state/plan
+	var data EndpointOauthResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// call api
+	res, err := r.state.getEndpointOauth(ctx, data.EnvironmentId.ValueString(), data.AppId.ValueString(), data.EndpointId.ValueString())
+	if err != nil {
+		logSvixReadError(ctx, resp, err, "Failed to read endpoint OAuth config")
+		return
+	}
+
+	// save state
+	// the client secret, refresh token and jwt params aren't returned, they are kept from the state
+	extraHeaders, diags := types.MapValueFrom(ctx, types.StringType, res.ExtraHeaders)
+	resp.Diagnostics.Append(diags...)
+	extraParams, diags := types.MapValueFrom(ctx, types.StringType, res.ExtraParams)
+	resp.Diagnostics.Append(diags...)
+	setReadState(ctx, resp, rp("token_url"), types.StringValue(res.TokenUrl))
+	setReadState(ctx, resp, rp("client_id"), types.StringValue(res.ClientId))
+	setReadState(ctx, resp, rp("scopes"), stringListOrNull(ctx, &resp.Diagnostics, res.Scopes))
+	setReadState(ctx, resp, rp("auth_method"), types.StringValue(res.AuthMethod))
+	setReadState(ctx, resp, rp("grant_type"), types.StringValue(res.GrantType))
+	setReadState(ctx, resp, rp("extra_headers"), mapOrNull(extraHeaders))
+	setReadState(ctx, resp, rp("extra_params"), mapOrNull(extraParams))
+}
+
+func (r *EndpointOauthResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// load state/plan
+	var data EndpointOauthResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configIn := data.configIn(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// call api
+	err := r.state.updateEndpointOauth(ctx, data.EnvironmentId.ValueString(), data.AppId.ValueString(), data.EndpointId.ValueString(), configIn)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to update endpoint OAuth config")
+		return
+	}
+
+	// save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EndpointOauthResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// load state/plan
+	var data EndpointOauthResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// call api
+	err := r.state.deleteEndpointOauth(ctx, data.EnvironmentId.ValueString(), data.AppId.ValueString(), data.EndpointId.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		logSvixError(&resp.Diagnostics, err, "Failed to delete endpoint OAuth config")
+	}
+}
+
+func (r *EndpointOauthResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data EndpointOauthResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the defaults aren't applied to the config yet
+	authMethod := data.AuthMethod.ValueString()
+	if data.AuthMethod.IsNull() {
+		authMethod = oauthAuthMethodClientSecretBasic
+	}
+	grantType := data.GrantType.ValueString()
+	if data.GrantType.IsNull() {
+		grantType = oauthGrantTypeClientCredentials
+	}
+
+	if !data.AuthMethod.IsUnknown() {
+		switch authMethod {
+		case oauthAuthMethodClientSecretBasic, oauthAuthMethodClientSecretPost:
+			if data.ClientSecret.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("client_secret"), "Missing Client Secret", fmt.Sprintf("`client_secret` is required with the `%s` auth method", authMethod))
+			}
+		case oauthAuthMethodClientSecretJwt:
+			if data.JwtParams.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("jwt_params"), "Missing JWT Params", fmt.Sprintf("`jwt_params` is required with the `%s` auth method", authMethod))
+			}
+		}
+	}
+	if !data.GrantType.IsUnknown() && grantType == oauthGrantTypeRefreshToken && data.RefreshToken.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("refresh_token"), "Missing Refresh Token", fmt.Sprintf("`refresh_token` is required with the `%s` grant type", grantType))
+	}
+}
+
+func (r *EndpointOauthResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+}
+
+func (r *EndpointOauthResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "app_id", "endpoint_id")
+}
+
+func (m *EndpointOauthResourceModel) configIn(ctx context.Context, d *diag.Diagnostics) endpointOauthConfigIn {
+	var scopes []string
+	var extraHeaders, extraParams map[string]string
+	d.Append(m.Scopes.ElementsAs(ctx, &scopes, false)...)
+	d.Append(m.ExtraHeaders.ElementsAs(ctx, &extraHeaders, false)...)
+	d.Append(m.ExtraParams.ElementsAs(ctx, &extraParams, false)...)
+
+	var jwtParams *clientSecretJwtParamsIn
+	if !m.JwtParams.IsNull() && !m.JwtParams.IsUnknown() {
+		var params EndpointOauthJwtParamsModel
+		d.Append(m.JwtParams.As(ctx, &params, basetypes.ObjectAsOptions{})...)
+		jwtParams = &clientSecretJwtParamsIn{
+			SecretBase64:     params.SecretBase64.ValueString(),
+			SecretId:         strOrNil(params.SecretId),
+			SigningAlgorithm: params.SigningAlgorithm.ValueString(),
+			TokenExpirySecs:  params.TokenExpirySecs.ValueInt64Pointer(),
+		}
+	}
+
+	return endpointOauthConfigIn{
+		AuthMethod:   m.AuthMethod.ValueString(),
+		ClientId:     m.ClientId.ValueString(),
+		ClientSecret: strOrNil(m.ClientSecret),
+		ExtraHeaders: extraHeaders,
+		ExtraParams:  extraParams,
+		GrantType:    m.GrantType.ValueString(),
+		JwtParams:    jwtParams,
+		RefreshToken: strOrNil(m.RefreshToken),
+		Scopes:       scopes,
+		TokenUrl:     m.TokenUrl.ValueString(),
+	}
+}
+
+// empty maps are returned as null by the api
+func mapOrNull(m types.Map) types.Map {
+	if len(m.Elements()) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	return m
+}
@@ -0,0 +1,451 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	svix "github.com/svix/svix-webhooks/go"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+var _ resource.Resource = &EndpointResource{}
+var _ resource.ResourceWithImportState = &EndpointResource{}
+var _ resource.ResourceWithModifyPlan = &EndpointResource{}
+
+type EndpointResource struct {
+	state *appState
+}
+
+type EndpointResourceModel struct {
+	EnvironmentId   types.String         `tfsdk:"environment_id"`
+	AppId           types.String         `tfsdk:"app_id"`
+	Channels        types.List           `tfsdk:"channels"`
+	CreatedAt       timetypes.RFC3339    `tfsdk:"created_at"`
+	Description     types.String         `tfsdk:"description"`
+	Disabled        types.Bool           `tfsdk:"disabled"`
+	FilterTypes     types.List           `tfsdk:"filter_types"`
+	Id              types.String         `tfsdk:"id"`
+	Metadata        jsontypes.Normalized `tfsdk:"metadata"`
+	RateLimit       types.Int32          `tfsdk:"rate_limit"`
+	Secret          types.String         `tfsdk:"secret"`
+	SecretWo        types.String         `tfsdk:"secret_wo"`
+	SecretWoVersion types.Int64          `tfsdk:"secret_wo_version"`
+	PreviousSecret  types.String         `tfsdk:"previous_secret"`
+	RotationTrigger types.Map            `tfsdk:"rotation_trigger"`
+	Uid             types.String         `tfsdk:"uid"`
+	UpdatedAt       timetypes.RFC3339    `tfsdk:"updated_at"`
+	Url             types.String         `tfsdk:"url"`
+	Version         types.Int32          `tfsdk:"version"`
+}
+
+func NewEndpointResource() resource.Resource {
+	return &EndpointResource{}
+}
+
+func (r *EndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *EndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "svix_endpoint"
+}
+
+func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "An endpoint of a `svix_application`. Messages sent to the application are delivered to all its matching endpoints.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: ENV_ID_DESC,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Id of the application this endpoint belongs to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"channels": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "List of message channels this endpoint listens to (omit for all)",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				}},
+			"created_at": schema.StringAttribute{
+				Computed:   true,
+				CustomType: timetypes.RFC3339Type{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{Computed: true, Optional: true, Default: stringdefault.StaticString("")},
+			"disabled":    schema.BoolAttribute{Computed: true, Optional: true, Default: booldefault.StaticBool(false)},
+			"filter_types": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "List of event types this endpoint listens to (omit for all)",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				}},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				}},
+			"metadata": schema.StringAttribute{
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				Default:             stringdefault.StaticString("{}"),
+				MarkdownDescription: "JSON object encoded as a string, use `jsonencode` to create this field",
+				Optional:            true,
+			},
+			"rate_limit": schema.Int32Attribute{Optional: true, Validators: []validator.Int32{
+				// uint16
+				int32validator.AtLeast(1),
+				int32validator.AtMost(65535),
+			}},
+			"uid": schema.StringAttribute{Optional: true, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.LengthAtMost(256),
+				stringvalidator.RegexMatches(saneStringRegex(), "String must match against `^[a-zA-Z0-9\\-_.]+$`"),
+			}},
+			"updated_at": schema.StringAttribute{
+				Computed:   true,
+				CustomType: timetypes.RFC3339Type{},
+			},
+			"url": schema.StringAttribute{Required: true},
+			"version": schema.Int32Attribute{Computed: true, Optional: true, Default: int32default.StaticInt32(1), Validators: []validator.Int32{
+				// uint16
+				int32validator.AtLeast(1),
+				int32validator.AtMost(65535),
+			}},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, endpointSecretAttributes())
+}
+
+func (r *EndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// load state/plan
+	var data EndpointResourceModel
+	var envId, appId string
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("app_id"), &appId)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	var epIn models.EndpointIn
+	{
+		metadata := stringToMapStringT[string](&resp.Diagnostics, data.Metadata.ValueStringPointer())
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var channels, filterTypes []string
+		resp.Diagnostics.Append(data.Channels.ElementsAs(ctx, &channels, true)...)
+		resp.Diagnostics.Append(data.FilterTypes.ElementsAs(ctx, &filterTypes, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var rateLimit *uint16
+		if !data.RateLimit.IsUnknown() && !data.RateLimit.IsNull() {
+			rateLimit = ptr(uint16(data.RateLimit.ValueInt32()))
+		}
+		var version *uint16
+		if !data.Version.IsUnknown() && !data.Version.IsNull() {
+			version = ptr(uint16(data.Version.ValueInt32()))
+		}
+
+		secret := endpointCreateSecret(ctx, req, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		epIn = models.EndpointIn{
+			Channels:    channels,
+			Description: strOrNil(data.Description),
+			Disabled:    boolOrNil(data.Disabled),
+			FilterTypes: filterTypes,
+			Metadata:    metadata,
+			RateLimit:   rateLimit,
+			Secret:      secret,
+			Uid:         strOrNil(data.Uid),
+			Url:         data.Url.ValueString(),
+			Version:     version,
+		}
+	}
+
+	res, err := svx.Endpoint.Create(ctx, appId, epIn, &svix.EndpointCreateOptions{IdempotencyKey: randStr32()})
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to create endpoint")
+		return
+	}
+	secretRes, err := svx.Endpoint.GetSecret(ctx, appId, res.Id)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to get endpoint secret")
+		return
+	}
+
+	// save state
+	metadataOut, err := json.Marshal(res.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Unable to marshal metadata to a string", err.Error())
+	}
+	var rateLimitOut *int32
+	if res.RateLimit != nil {
+		rateLimitOut = ptr(int32(*res.RateLimit))
+	}
+
+	setCreateState(ctx, resp, rp("environment_id"), envId)
+	setCreateState(ctx, resp, rp("app_id"), appId)
+	setCreateState(ctx, resp, rp("channels"), stringListOrNull(ctx, &resp.Diagnostics, res.Channels))
+	setCreateState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setCreateState(ctx, resp, rp("description"), types.StringValue(res.Description))
+	setCreateState(ctx, resp, rp("disabled"), types.BoolPointerValue(res.Disabled))
+	setCreateState(ctx, resp, rp("filter_types"), stringListOrNull(ctx, &resp.Diagnostics, res.FilterTypes))
+	setCreateState(ctx, resp, rp("id"), types.StringValue(res.Id))
+	setCreateState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
+	setCreateState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
+	setCreateState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.Plan, &resp.Diagnostics, secretRes.Key))
+	setCreateState(ctx, resp, rp("previous_secret"), types.StringNull())
+	setCreateState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
+	setCreateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
+	setCreateState(ctx, resp, rp("url"), types.StringValue(res.Url))
+	setCreateState(ctx, resp, rp("version"), types.Int32Value(res.Version))
+}
+
+func (r *EndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// load state/plan
+	var envId, appId, endpId string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("app_id"), &appId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &endpId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	res, err := svx.Endpoint.Get(ctx, appId, endpId)
+	if err != nil {
+		logSvixReadError(ctx, resp, err, "Failed to get endpoint")
+		return
+	}
+	secretRes, err := svx.Endpoint.GetSecret(ctx, appId, res.Id)
+	if err != nil {
+		logSvixReadError(ctx, resp, err, "Failed to get endpoint secret")
+		return
+	}
+
+	// save state
+	metadataOut, err := json.Marshal(res.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Unable to marshal metadata to a string", err.Error())
+	}
+	var rateLimitOut *int32
+	if res.RateLimit != nil {
+		rateLimitOut = ptr(int32(*res.RateLimit))
+	}
+
+	setReadState(ctx, resp, rp("environment_id"), envId)
+	setReadState(ctx, resp, rp("app_id"), appId)
+	setReadState(ctx, resp, rp("channels"), stringListOrNull(ctx, &resp.Diagnostics, res.Channels))
+	setReadState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setReadState(ctx, resp, rp("description"), types.StringValue(res.Description))
+	setReadState(ctx, resp, rp("disabled"), types.BoolPointerValue(res.Disabled))
+	setReadState(ctx, resp, rp("filter_types"), stringListOrNull(ctx, &resp.Diagnostics, res.FilterTypes))
+	setReadState(ctx, resp, rp("id"), types.StringValue(res.Id))
+	setReadState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
+	setReadState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
+	setReadState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.State, &resp.Diagnostics, secretRes.Key))
+	setReadState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
+	setReadState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
+	setReadState(ctx, resp, rp("url"), types.StringValue(res.Url))
+	setReadState(ctx, resp, rp("version"), types.Int32Value(res.Version))
+}
+
+func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// load state/plan
+	var data EndpointResourceModel
+	var envId, appId, endpId string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("app_id"), &appId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &endpId)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	var epUpdate models.EndpointUpdate
+	{
+		metadata := stringToMapStringT[string](&resp.Diagnostics, data.Metadata.ValueStringPointer())
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var channels, filterTypes []string
+		resp.Diagnostics.Append(data.Channels.ElementsAs(ctx, &channels, true)...)
+		resp.Diagnostics.Append(data.FilterTypes.ElementsAs(ctx, &filterTypes, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var rateLimit *uint16
+		if !data.RateLimit.IsUnknown() && !data.RateLimit.IsNull() {
+			rateLimit = ptr(uint16(data.RateLimit.ValueInt32()))
+		}
+		var version *uint16
+		if !data.Version.IsUnknown() && !data.Version.IsNull() {
+			version = ptr(uint16(data.Version.ValueInt32()))
+		}
+
+		epUpdate = models.EndpointUpdate{
+			Channels:    channels,
+			Description: strOrNil(data.Description),
+			Disabled:    boolOrNil(data.Disabled),
+			FilterTypes: filterTypes,
+			Metadata:    metadata,
+			RateLimit:   rateLimit,
+			Uid:         strOrNil(data.Uid),
+			Url:         data.Url.ValueString(),
+			Version:     version,
+		}
+	}
+
+	res, err := svx.Endpoint.Update(ctx, appId, endpId, epUpdate)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to update endpoint")
+		return
+	}
+	rotate, key := endpointSecretRotation(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if rotate {
+		err = svx.Endpoint.RotateSecret(ctx, appId, endpId, models.EndpointSecretRotateIn{Key: key}, &svix.EndpointRotateSecretOptions{IdempotencyKey: randStr32()})
+		if err != nil {
+			logSvixError(&resp.Diagnostics, err, "Failed to rotate endpoint secret")
+			return
+		}
+	}
+	secretRes, err := svx.Endpoint.GetSecret(ctx, appId, res.Id)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to get endpoint secret")
+		return
+	}
+
+	// save state
+	metadataOut, err := json.Marshal(res.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Unable to marshal metadata to a string", err.Error())
+	}
+	var rateLimitOut *int32
+	if res.RateLimit != nil {
+		rateLimitOut = ptr(int32(*res.RateLimit))
+	}
+
+	setUpdateState(ctx, resp, rp("environment_id"), envId)
+	setUpdateState(ctx, resp, rp("app_id"), appId)
+	setUpdateState(ctx, resp, rp("channels"), stringListOrNull(ctx, &resp.Diagnostics, res.Channels))
+	setUpdateState(ctx, resp, rp("created_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setUpdateState(ctx, resp, rp("description"), types.StringValue(res.Description))
+	setUpdateState(ctx, resp, rp("disabled"), types.BoolPointerValue(res.Disabled))
+	setUpdateState(ctx, resp, rp("filter_types"), stringListOrNull(ctx, &resp.Diagnostics, res.FilterTypes))
+	setUpdateState(ctx, resp, rp("id"), types.StringValue(res.Id))
+	setUpdateState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
+	setUpdateState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
+	setUpdateState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.Plan, &resp.Diagnostics, secretRes.Key))
+	setUpdateState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
+	setUpdateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
+	setUpdateState(ctx, resp, rp("url"), types.StringValue(res.Url))
+	setUpdateState(ctx, resp, rp("version"), types.Int32Value(res.Version))
+}
+
+func (r *EndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// load state/plan
+	var envId, appId, endpId string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("app_id"), &appId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &endpId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	err = svx.Endpoint.Delete(ctx, appId, endpId)
+	if err != nil && !isNotFoundError(err) {
+		logSvixError(&resp.Diagnostics, err, "Failed to delete endpoint")
+		return
+	}
+}
+
+func (r *EndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+	planEndpointSecret(ctx, req, resp)
+}
+
+func (r *EndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "app_id", "id")
+}
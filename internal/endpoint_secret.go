@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// endpoint secrets are `whsec_` followed by base64 encoded random bytes
+var endpointSecretRegex = regexp.MustCompile(`^whsec_[a-zA-Z0-9+/=]{32,100}$`)
+
+// the `secret`, `secret_wo`, `secret_wo_version`, `previous_secret` and `rotation_trigger` attributes of the endpoint resources
+func endpointSecretAttributes() map[string]schema.Attribute {
+	secretValidator := stringvalidator.RegexMatches(endpointSecretRegex, "Must be `whsec_` followed by 32 to 100 base64 characters")
+	return map[string]schema.Attribute{
+		"secret": schema.StringAttribute{
+			Sensitive: true,
+			Optional:  true,
+			Computed:  true,
+			MarkdownDescription: "The endpoint's verification secret.\n" +
+				"Format: base64 encoded random bytes prefixed with whsec_. If not set, the server generates the secret.\n" +
+				"Changing it rotates the secret, the previous secret stays valid for 24 hours.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.String{
+				secretValidator,
+				stringvalidator.ConflictsWith(path.MatchRoot("secret_wo")),
+			},
+		},
+		"secret_wo": schema.StringAttribute{
+			Sensitive: true,
+			Optional:  true,
+			WriteOnly: true,
+			MarkdownDescription: "Write-only alternative to `secret` (requires Terraform 1.11 or later), the secret isn't saved in the plan or state: " +
+				"`secret` and `previous_secret` are null when it is set. Change `secret_wo_version` to rotate the secret to a new `secret_wo`.",
+			Validators: []validator.String{
+				secretValidator,
+				stringvalidator.AlsoRequires(path.MatchRoot("secret_wo_version")),
+			},
+		},
+		"secret_wo_version": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Version of `secret_wo`, changing it rotates the secret to the current value of `secret_wo`.",
+			Validators: []validator.Int64{
+				int64validator.AlsoRequires(path.MatchRoot("secret_wo")),
+			},
+		},
+		"previous_secret": schema.StringAttribute{
+			Sensitive:           true,
+			Computed:            true,
+			MarkdownDescription: "The verification secret before the last rotation, Svix keeps signing messages with it for 24 hours after the rotation.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"rotation_trigger": schema.MapAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			MarkdownDescription: "Arbitrary map of values that, when changed, rotates the secret generated by the server " +
+				"(e.g. `{ rotated_at = \"2025-01-01\" }`). Not used if `secret` or `secret_wo` is set.",
+		},
+	}
+}
+
+// the secret to create the endpoint with, nil to let the server generate it
+func endpointCreateSecret(ctx context.Context, req resource.CreateRequest, d *diag.Diagnostics) *string {
+	var secret, secretWo types.String
+	d.Append(req.Plan.GetAttribute(ctx, rp("secret"), &secret)...)
+	d.Append(req.Config.GetAttribute(ctx, rp("secret_wo"), &secretWo)...)
+	if !secretWo.IsNull() {
+		return secretWo.ValueStringPointer()
+	}
+	return strOrNil(secret)
+}
+
+// plan a secret rotation when `secret`, `secret_wo_version` or `rotation_trigger` changes, `previous_secret` is set to the current secret.
+// When `secret` isn't set, the new secret is only known after the rotation.
+//
+// A write-only secret (`secret_wo`) is never saved, so `secret` and `previous_secret` are planned as null when it is set
+func planEndpointSecret(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configSecret, configSecretWo, stateSecret types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, rp("secret"), &configSecret)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, rp("secret_wo"), &configSecretWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !configSecretWo.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("secret"), types.StringNull())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("previous_secret"), types.StringNull())...)
+	}
+
+	// nothing to rotate on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var planWoVersion, stateWoVersion types.Int64
+	var planTrigger, stateTrigger types.Map
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, rp("secret"), &stateSecret)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, rp("secret_wo_version"), &planWoVersion)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, rp("secret_wo_version"), &stateWoVersion)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, rp("rotation_trigger"), &planTrigger)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, rp("rotation_trigger"), &stateTrigger)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secretChanged := !configSecret.IsNull() && !configSecret.Equal(stateSecret)
+	secretWoChanged := !configSecretWo.IsNull() && !planWoVersion.Equal(stateWoVersion)
+	triggerChanged := configSecret.IsNull() && configSecretWo.IsNull() && !planTrigger.Equal(stateTrigger)
+	// a write-only secret is rotated in the update, but neither it nor the previous secret is saved
+	if secretWoChanged || !secretChanged && !triggerChanged {
+		return
+	}
+	if configSecret.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("secret"), types.StringUnknown())...)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("previous_secret"), stateSecret)...)
+}
+
+// the state or plan of a resource
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target interface{}) diag.Diagnostics
+}
+
+// the `secret` to save in the state, null if the secret is write-only (`secret_wo_version` is only set together with `secret_wo`)
+func endpointSecretState(ctx context.Context, data attributeGetter, d *diag.Diagnostics, key string) types.String {
+	var secretWoVersion types.Int64
+	d.Append(data.GetAttribute(ctx, rp("secret_wo_version"), &secretWoVersion)...)
+	if !secretWoVersion.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(key)
+}
+
+// whether the planned secret differs from the current one, and the key to rotate to (nil to let the server generate it)
+func endpointSecretRotation(ctx context.Context, req resource.UpdateRequest, d *diag.Diagnostics) (bool, *string) {
+	var planSecret, stateSecret, configSecretWo types.String
+	var planWoVersion, stateWoVersion types.Int64
+	d.Append(req.Plan.GetAttribute(ctx, rp("secret"), &planSecret)...)
+	d.Append(req.State.GetAttribute(ctx, rp("secret"), &stateSecret)...)
+	d.Append(req.Config.GetAttribute(ctx, rp("secret_wo"), &configSecretWo)...)
+	d.Append(req.Plan.GetAttribute(ctx, rp("secret_wo_version"), &planWoVersion)...)
+	d.Append(req.State.GetAttribute(ctx, rp("secret_wo_version"), &stateWoVersion)...)
+	if d.HasError() {
+		return false, nil
+	}
+	if !configSecretWo.IsNull() {
+		// the write-only secret is rotated when its version changes
+		return !planWoVersion.Equal(stateWoVersion), configSecretWo.ValueStringPointer()
+	}
+	if planSecret.IsUnknown() {
+		// rotated by `rotation_trigger`, unless the secret was write-only until now and is only read back
+		return stateWoVersion.IsNull(), nil
+	}
+	return !planSecret.Equal(stateSecret), planSecret.ValueStringPointer()
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &EndpointSecretEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &EndpointSecretEphemeralResource{}
+
+func NewEndpointSecretEphemeralResource() ephemeral.EphemeralResource {
+	return &EndpointSecretEphemeralResource{}
+}
+
+type EndpointSecretEphemeralResource struct {
+	state *appState
+}
+
+type EndpointSecretEphemeralResourceModel struct {
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	AppId         types.String `tfsdk:"app_id"`
+	EndpointId    types.String `tfsdk:"endpoint_id"`
+	Secret        types.String `tfsdk:"secret"`
+}
+
+func (r *EndpointSecretEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "svix_endpoint_secret"
+}
+
+func (r *EndpointSecretEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *EndpointSecretEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetch the signing secret of an application endpoint without storing it in the state",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The Id of the environment the endpoint belongs to, defaults to the provider's `environment_id`",
+			},
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Id or uid of the application the endpoint belongs to",
+			},
+			"endpoint_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Id or uid of the endpoint",
+			},
+			"secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The endpoint's signing secret, prefixed with `whsec_`",
+			},
+		},
+	}
+}
+
+func (r *EndpointSecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// load config
+	var data EndpointSecretEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.EnvironmentId = types.StringValue(r.state.environmentIdOrDefault(ctx, &resp.Diagnostics, data.EnvironmentId))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	res, err := svx.Endpoint.GetSecret(ctx, data.AppId.ValueString(), data.EndpointId.ValueString())
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to get endpoint secret")
+		return
+	}
+
+	// save result
+	data.Secret = types.StringValue(res.Key)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,282 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	svix "github.com/svix/svix-webhooks/go"
+	"github.com/svix/svix-webhooks/go/models"
+	"github.com/svix/svix-webhooks/go/utils"
+)
+
+var _ resource.Resource = &EndpointTransformationResource{}
+var _ resource.ResourceWithImportState = &EndpointTransformationResource{}
+var _ resource.ResourceWithModifyPlan = &EndpointTransformationResource{}
+
+func NewEndpointTransformationResource() resource.Resource {
+	return &EndpointTransformationResource{}
+}
+
+type EndpointTransformationResource struct {
+	state *appState
+}
+
+type EndpointTransformationResourceModel struct {
+	EnvironmentId  types.String       `tfsdk:"environment_id"`
+	AppId          types.String       `tfsdk:"app_id"`
+	IngestSourceId types.String       `tfsdk:"ingest_source_id"`
+	EndpointId     types.String       `tfsdk:"endpoint_id"`
+	Code           transformationCode `tfsdk:"code"`
+	Enabled        types.Bool         `tfsdk:"enabled"`
+}
+
+// transformation of an application or ingest endpoint
+type endpointTransformation struct {
+	Code    *string
+	Enabled *bool
+}
+
+func (r *EndpointTransformationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "svix_endpoint_transformation"
+}
+
+func (r *EndpointTransformationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The JavaScript transformation of an application endpoint (`svix_endpoint`) or an ingest endpoint (`svix_ingest_endpoint`).\n\n" +
+			"Transformations must be enabled with `enable_transformations` in `svix_environment_settings`.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: ENV_ID_DESC,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The application of the endpoint, for an application endpoint",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("ingest_source_id")),
+				},
+			},
+			"ingest_source_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ingest source of the endpoint, for an ingest endpoint",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"code": schema.StringAttribute{
+				Required:   true,
+				CustomType: transformationCodeType{},
+				MarkdownDescription: "The JavaScript code of the transformation, which must define a `handler` function. " +
+					"Use `file()` to load it from a `.js` file.\n\n" +
+					"Differences in whitespace (line endings, trailing whitespace and surrounding blank lines) are ignored, " +
+					"and the syntax of the code is checked during the plan.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the transformation is applied to the messages sent to the endpoint, defaults to `true`",
+			},
+		},
+	}
+}
+
+func (r *EndpointTransformationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *EndpointTransformationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// load state/plan
+	var data EndpointTransformationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	code := normalizeTransformationCode(data.Code.ValueString())
+	err = data.setTransformation(ctx, svx, endpointTransformation{Code: &code, Enabled: data.Enabled.ValueBoolPointer()})
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to set endpoint transformation")
+		return
+	}
+
+	// save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EndpointTransformationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// load state/plan
+	var data EndpointTransformationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	res, err := data.getTransformation(ctx, svx)
+	if err != nil {
+		logSvixReadError(ctx, resp, err, "Failed to read endpoint transformation")
+		return
+	}
+	// the transformation was removed outside of terraform
+	if res.Code == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save state
+	setReadState(ctx, resp, rp("code"), newTransformationCodeValue(*res.Code))
+	setReadState(ctx, resp, rp("enabled"), types.BoolValue(res.Enabled != nil && *res.Enabled))
+}
+
+func (r *EndpointTransformationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// load state/plan
+	var data EndpointTransformationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	code := normalizeTransformationCode(data.Code.ValueString())
+	err = data.setTransformation(ctx, svx, endpointTransformation{Code: &code, Enabled: data.Enabled.ValueBoolPointer()})
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to update endpoint transformation")
+		return
+	}
+
+	// save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EndpointTransformationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// load state/plan
+	var data EndpointTransformationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	// disable the transformation and remove its code. If the endpoint was deleted, so was its transformation
+	err = data.setTransformation(ctx, svx, endpointTransformation{Code: nil, Enabled: ptr(false)})
+	if err != nil && !isNotFoundError(err) {
+		logSvixError(&resp.Diagnostics, err, "Failed to remove endpoint transformation")
+	}
+}
+
+func (r *EndpointTransformationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+}
+
+// import from `<environment_id>/<app_id>/<endpoint_id>` for an application endpoint
+// and `<environment_id>/ingest/<ingest_source_id>/<endpoint_id>` for an ingest endpoint
+func (r *EndpointTransformationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	switch {
+	case len(parts) == 3:
+		importStateFromId(ctx, req, resp, "environment_id", "app_id", "endpoint_id")
+	case len(parts) == 4 && parts[1] == "ingest":
+		req.ID = strings.Join([]string{parts[0], parts[2], parts[3]}, "/")
+		importStateFromId(ctx, req, resp, "environment_id", "ingest_source_id", "endpoint_id")
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: `environment_id/app_id/endpoint_id` or `environment_id/ingest/ingest_source_id/endpoint_id`. Got: %q", req.ID),
+		)
+	}
+}
+
+func (m *EndpointTransformationResourceModel) getTransformation(ctx context.Context, svx *svix.Svix) (*endpointTransformation, error) {
+	endpointId := m.EndpointId.ValueString()
+	if !m.IngestSourceId.IsNull() {
+		res, err := svx.Ingest.Endpoint.GetTransformation(ctx, m.IngestSourceId.ValueString(), endpointId)
+		if err != nil {
+			return nil, err
+		}
+		return &endpointTransformation{Code: res.Code, Enabled: res.Enabled}, nil
+	}
+	res, err := svx.Endpoint.TransformationGet(ctx, m.AppId.ValueString(), endpointId)
+	if err != nil {
+		return nil, err
+	}
+	return &endpointTransformation{Code: res.Code, Enabled: res.Enabled}, nil
+}
+
+// set the code and enabled state of the transformation, a nil code removes it
+func (m *EndpointTransformationResourceModel) setTransformation(ctx context.Context, svx *svix.Svix, t endpointTransformation) error {
+	endpointId := m.EndpointId.ValueString()
+	if !m.IngestSourceId.IsNull() {
+		return svx.Ingest.Endpoint.SetTransformation(ctx, m.IngestSourceId.ValueString(), endpointId, models.IngestEndpointTransformationPatch{
+			Code:    utils.NewNullableFromPtr(t.Code),
+			Enabled: t.Enabled,
+		})
+	}
+	return svx.Endpoint.PatchTransformation(ctx, m.AppId.ValueString(), endpointId, models.EndpointTransformationPatch{
+		Code:    utils.NewNullableFromPtr(t.Code),
+		Enabled: t.Enabled,
+	})
+}
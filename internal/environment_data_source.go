@@ -0,0 +1,225 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	svix_internal "github.com/svix/svix-webhooks/go/internalapi"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+var _ datasource.DataSource = &EnvironmentDataSource{}
+var _ datasource.DataSource = &EnvironmentsDataSource{}
+
+func NewEnvironmentDataSource() datasource.DataSource {
+	return &EnvironmentDataSource{}
+}
+
+func NewEnvironmentsDataSource() datasource.DataSource {
+	return &EnvironmentsDataSource{}
+}
+
+type EnvironmentDataSource struct {
+	state *appState
+}
+
+type EnvironmentsDataSource struct {
+	state *appState
+}
+
+type EnvironmentsDataSourceModel struct {
+	Environments []EnvironmentResourceModel `tfsdk:"environments"`
+}
+
+func environmentDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id":         schema.StringAttribute{Computed: true},
+		"name":       schema.StringAttribute{Computed: true},
+		"type":       schema.StringAttribute{Computed: true},
+		"region":     schema.StringAttribute{Computed: true},
+		"created_at": schema.StringAttribute{Computed: true, CustomType: timetypes.RFC3339Type{}},
+		"updated_at": schema.StringAttribute{Computed: true, CustomType: timetypes.RFC3339Type{}},
+	}
+}
+
+func environmentOutToModel(res models.EnvironmentModelOut) EnvironmentResourceModel {
+	return EnvironmentResourceModel{
+		Id:        types.StringValue(res.Id),
+		Name:      types.StringValue(res.Name),
+		Type:      types.StringValue(string(res.Type)),
+		Region:    types.StringValue(string(res.Region)),
+		CreatedAt: timetypes.NewRFC3339TimeValue(res.CreatedAt),
+		UpdatedAt: timetypes.NewRFC3339TimeValue(res.UpdatedAt),
+	}
+}
+
+// list all the environments the token has access to
+func listEnvironments(ctx context.Context, svx *svix_internal.InternalSvix) ([]models.EnvironmentModelOut, error) {
+	var envs []models.EnvironmentModelOut
+	opts := svix_internal.ManagementEnvironmentListOptions{Limit: ptr(uint64(250))}
+	for {
+		res, err := svx.Management.Environment.List(ctx, &opts)
+		if err != nil {
+			return nil, err
+		}
+		envs = append(envs, res.Data...)
+		if res.Done || res.Iterator == nil {
+			return envs, nil
+		}
+		opts.Iterator = res.Iterator
+	}
+}
+
+func (d *EnvironmentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "svix_environment"
+}
+
+func (d *EnvironmentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.state = state
+}
+
+func (d *EnvironmentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := environmentDataSourceAttributes()
+	attrs["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The Id of the environment, exactly one of `id` or `name` must be set",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+		},
+	}
+	attrs["name"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The name of the environment, exactly one of `id` or `name` must be set",
+	}
+	resp.Schema = schema.Schema{
+		Description: "Look up a single environment by id or name",
+		Attributes:  attrs,
+	}
+}
+
+func (d *EnvironmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// load config
+	var data EnvironmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := d.state.InternalDefaultSvixClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	var env models.EnvironmentModelOut
+	if !data.Id.IsNull() {
+		res, err := svx.Management.Environment.Get(ctx, data.Id.ValueString())
+		if err != nil {
+			logSvixError(&resp.Diagnostics, err, "Failed to read environment")
+			return
+		}
+		env = *res
+	} else {
+		envs, err := listEnvironments(ctx, svx)
+		if err != nil {
+			logSvixError(&resp.Diagnostics, err, "Failed to list environments")
+			return
+		}
+		var matches []models.EnvironmentModelOut
+		for _, e := range envs {
+			if e.Name == data.Name.ValueString() {
+				matches = append(matches, e)
+			}
+		}
+		if len(matches) != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Unable to find environment",
+				fmt.Sprintf("Expected exactly one environment named %q, found %d", data.Name.ValueString(), len(matches)),
+			)
+			return
+		}
+		env = matches[0]
+	}
+
+	// save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, environmentOutToModel(env))...)
+}
+
+func (d *EnvironmentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "svix_environments"
+}
+
+func (d *EnvironmentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.state = state
+}
+
+func (d *EnvironmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List all the environments the token has access to",
+		Attributes: map[string]schema.Attribute{
+			"environments": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: environmentDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *EnvironmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// create svix client
+	svx, err := d.state.InternalDefaultSvixClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	envs, err := listEnvironments(ctx, svx)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to list environments")
+		return
+	}
+
+	// save state
+	data := EnvironmentsDataSourceModel{Environments: []EnvironmentResourceModel{}}
+	for _, env := range envs {
+		data.Environments = append(data.Environments, environmentOutToModel(env))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
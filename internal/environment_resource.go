@@ -18,13 +18,14 @@ import (
 )
 
 var _ resource.Resource = &EnvironmentResource{}
+var _ resource.ResourceWithImportState = &EnvironmentResource{}
 
 func NewEnvironmentResource() resource.Resource {
 	return &EnvironmentResource{}
 }
 
 type EnvironmentResource struct {
-	state appState
+	state *appState
 }
 
 type EnvironmentResourceModel struct {
@@ -40,11 +41,11 @@ func (r *EnvironmentResource) Configure(ctx context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	state, ok := req.ProviderData.(appState)
+	state, ok := req.ProviderData.(*appState)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -105,7 +106,7 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// create svix client
-	svx, err := r.state.InternalDefaultSvixClient()
+	svx, err := r.state.InternalDefaultSvixClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -142,7 +143,7 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	// create svix client
-	svx, err := r.state.InternalDefaultSvixClient()
+	svx, err := r.state.InternalDefaultSvixClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -151,7 +152,7 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 	// call api
 	res, err := svx.Management.Environment.Get(ctx, env_id)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to read environment")
+		logSvixReadError(ctx, resp, err, "Failed to read environment")
 		return
 	}
 
@@ -175,7 +176,7 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	// create svix client
-	svx, err := r.state.InternalDefaultSvixClient()
+	svx, err := r.state.InternalDefaultSvixClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -208,7 +209,7 @@ func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	// create svix client
-	svx, err := r.state.InternalDefaultSvixClient()
+	svx, err := r.state.InternalDefaultSvixClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -217,8 +218,12 @@ func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteReq
 	// call api
 
 	err = svx.Management.Environment.Delete(ctx, env_id)
-	if err != nil {
+	if err != nil && !isNotFoundError(err) {
 		logSvixError(&resp.Diagnostics, err, "Failed to delete environment")
 		return
 	}
 }
+
+func (r *EnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "id")
+}
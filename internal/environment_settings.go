@@ -2,10 +2,14 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -13,8 +17,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -25,13 +31,59 @@ import (
 )
 
 var _ resource.Resource = &EnvironmentSettingsResource{}
+var _ resource.ResourceWithImportState = &EnvironmentSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &EnvironmentSettingsResource{}
 
 func NewEnvironmentSettingsResource() resource.Resource {
 	return &EnvironmentSettingsResource{}
 }
 
 type EnvironmentSettingsResource struct {
-	state appState
+	state *appState
+}
+
+const (
+	onDestroyKeep            = "keep"
+	onDestroyReset           = "reset"
+	onDestroySnapshotRestore = "snapshot_restore"
+
+	// private state key of the settings captured before terraform managed them
+	settingsSnapshotKey = "settings_snapshot"
+)
+
+// settings of an environment, as saved in the private state for `on_destroy = "snapshot_restore"`
+type environmentSettingsSnapshot struct {
+	Settings   models.SettingsInternalOut `json:"settings"`
+	OtelConfig *models.OtelConfigOut      `json:"otelConfig,omitempty"`
+}
+
+// the default settings of a new environment (from the svix api docs), used by `on_destroy = "reset"`
+var defaultEnvironmentSettings = environmentSettingsSnapshot{
+	Settings: models.SettingsInternalOut{
+		DisableEndpointOnFailure:   ptr(true),
+		EnableChannels:             ptr(false),
+		EnableEndpointMtlsConfig:   ptr(false),
+		EnableEndpointOauthConfig:  ptr(false),
+		EnableMessageStream:        ptr(false),
+		EnableOtlp:                 ptr(false),
+		EnableTransformations:      ptr(false),
+		EnforceHttps:               ptr(true),
+		EventCatalogPublished:      ptr(false),
+		RequireEndpointChannel:     ptr(false),
+		RequireEndpointFilterTypes: ptr(false),
+		WhitelabelHeaders:          ptr(false),
+		WipeSuccessfulPayload:      ptr(false),
+
+		ReadOnly:                    ptr(false),
+		EnableApplicationAlerts:     ptr(false),
+		ApplicationAlertEvents:      []models.ApplicationAlertEvent{},
+		EnableIntegrationManagement: ptr(false),
+		EnableMsgAtmptLog:           ptr(false),
+		SendSvixWebhookHeaders:      ptr(false),
+		ShowFeatureTooltips:         ptr(false),
+		ShowUseSvixPlay:             ptr(true),
+		WebhooksAutoConfig:          ptr(false),
+	},
 }
 
 var borderRadiusEnum = []string{
@@ -42,6 +94,12 @@ var borderRadiusEnum = []string{
 	"full",
 }
 
+var applicationAlertEventEnum = []string{
+	string(models.APPLICATIONALERTEVENT_ENDPOINT_DISABLED),
+	string(models.APPLICATIONALERTEVENT_MESSAGE_ATTEMPT_EXHAUSTED),
+	string(models.APPLICATIONALERTEVENT_STREAM_SINK_DISABLED),
+}
+
 var fontFamilyEnum = []string{
 	"Helvetica",
 	"Roboto",
@@ -123,12 +181,19 @@ func (r *EnvironmentSettingsResource) Metadata(ctx context.Context, req resource
 
 func (r *EnvironmentSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "The settings of an environment.\n\n" +
+			"Some settings are only available on the Pro or Enterprise plans (`enable_advanced_endpoint_types`, `enable_endpoint_mtls_config`, " +
+			"`enable_endpoint_oauth_config`, `whitelabel_headers`, `delete_payload_on_successful_delivery` and `otel_config`). " +
+			"The Svix API doesn't expose the organization's plan, so the provider can't check it. When any of these settings is newly enabled, " +
+			"the plan shows a single warning listing them, whatever the organization's plan is. If the organization isn't on the required plan, " +
+			"the apply fails with a 403 error that lists them.",
 		Attributes: map[string]schema.Attribute{
 			"environment_id": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: ENV_ID_DESC,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"whitelabel_settings": schema.SingleNestedAttribute{
@@ -336,6 +401,119 @@ method, destination URL, and payload body in-flight.`,
 delivered to the endpoint. Only affects messages sent after this
 setting is enabled.`,
 			},
+			"read_only": schema.BoolAttribute{
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Read-only App Portal",
+				MarkdownDescription: "Makes the Consumer App Portal read-only, users can see their endpoints and messages but can't change them.",
+			},
+			"enable_application_alerts": schema.BoolAttribute{
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Application alerts",
+				MarkdownDescription: "Alert your users when one of the `application_alert_events` happens to one of their endpoints.",
+			},
+			"application_alert_events": schema.ListAttribute{
+				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+				Optional:      true,
+				Computed:      true,
+				ElementType:   types.StringType,
+				Description:   "Application alert events",
+				MarkdownDescription: "The events your users are alerted about, any of `" +
+					strings.Join(applicationAlertEventEnum, "`, `") + "`",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(applicationAlertEventEnum...)),
+				},
+			},
+			"application_alerts_dashboard_url": schema.StringAttribute{
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Application alerts dashboard URL",
+				MarkdownDescription: "URL of your dashboard, linked from the application alerts",
+			},
+			"application_alerts_logo_url": schema.StringAttribute{
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Application alerts logo URL",
+				MarkdownDescription: "URL of the logo shown in the application alerts",
+			},
+			"enable_integration_management": schema.BoolAttribute{
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Integration management",
+				MarkdownDescription: "Allows users to manage the integrations of their applications from the Consumer App Portal.",
+			},
+			"enable_message_attempt_log": schema.BoolAttribute{
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Message attempt log",
+				MarkdownDescription: "Enables the message attempt log of the environment.",
+			},
+			"send_svix_webhook_headers": schema.BoolAttribute{
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Send Svix webhook headers",
+				MarkdownDescription: "Also send the `svix-` prefixed webhook headers when `whitelabel_headers` is enabled, to ease the migration of existing integrations.",
+			},
+			"show_feature_tooltips": schema.BoolAttribute{
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Feature tooltips",
+				MarkdownDescription: "Show tooltips explaining the features of the Consumer App Portal.",
+			},
+			"show_use_svix_play": schema.BoolAttribute{
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Svix Play",
+				MarkdownDescription: "Show the option to create a test endpoint with [Svix Play](https://www.svix.com/play/) in the Consumer App Portal.",
+			},
+			"webhooks_auto_config": schema.BoolAttribute{
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Optional:            true,
+				Computed:            true,
+				Description:         "Webhooks auto-configuration",
+				MarkdownDescription: "Allows endpoints to be configured automatically by the receiving application.",
+			},
+			"retry_schedule": schema.ListAttribute{
+				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+				Optional:      true,
+				Computed:      true,
+				ElementType:   types.Int64Type,
+				Description:   "Retry schedule",
+				MarkdownDescription: "Custom retry schedule of the environment: the delays, in seconds, between the delivery attempts of a message. " +
+					"The [default schedule](https://docs.svix.com/retries) is used when not set.",
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(0, math.MaxInt32)),
+				},
+			},
+			"reset_unmanaged_settings": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "By default, settings that are removed from the configuration keep their current value. " +
+					"If `true`, the settings that aren't set in the configuration, including the attributes of `whitelabel_settings`, " +
+					"are reset to their Svix default when applying, and the plan shows them being reset.",
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(onDestroyKeep),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyKeep, onDestroyReset, onDestroySnapshotRestore),
+				},
+				MarkdownDescription: "What happens to the settings when this resource is destroyed:\n" +
+					"  - `keep`: the settings are left as they are (default)\n" +
+					"  - `reset`: the settings are reset to the Svix defaults, and the OTEL config is deleted\n" +
+					"  - `snapshot_restore`: the settings are restored to what they were before terraform managed them " +
+					"(captured when the resource is created or imported)",
+			},
 			"otel_config": schema.SingleNestedAttribute{
 				Optional:            true,
 				MarkdownDescription: REQUIRES_ENTERPRISE_PLAN + "Configure OpenTelemetry (OTEL) tracing for this environment. Setting this block enables OpenTelemetry exports; removing it disables exports and deletes the stored config.",
@@ -361,11 +539,11 @@ func (r *EnvironmentSettingsResource) Configure(ctx context.Context, req resourc
 	if req.ProviderData == nil {
 		return
 	}
-	state, ok := req.ProviderData.(appState)
+	state, ok := req.ProviderData.(*appState)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -384,7 +562,7 @@ func (r *EnvironmentSettingsResource) Create(ctx context.Context, req resource.C
 	envId := data.EnvironmentId.ValueString()
 
 	// create svix client
-	svx, err := r.state.InternalClientWithEnvId(envId)
+	svx, err := r.state.InternalClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -397,6 +575,12 @@ func (r *EnvironmentSettingsResource) Create(ctx context.Context, req resource.C
 
 	currentOtel, _ := svx.Management.EnvironmentSettings.GetOtelConfig(ctx)
 
+	// save the current settings, so they can be restored on destroy
+	resp.Diagnostics.Append(saveSettingsSnapshot(ctx, svx, currentOtel, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	otelConfigOut, diags := applyOtelConfig(ctx, svx, data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -415,24 +599,30 @@ func (r *EnvironmentSettingsResource) Create(ctx context.Context, req resource.C
 	// call api
 	res, err := svx.Management.EnvironmentSettings.Patch(ctx, settingsPatch)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to patch environment settings")
+		logSettingsPatchError(&resp.Diagnostics, err, data, "Failed to patch environment settings")
 		return
 	}
 
 	outModel := internalSettingsOutToTF(ctx, &resp.Diagnostics, *res, envId, otelConfigOut)
+	outModel.ResetUnmanagedSettings = data.ResetUnmanagedSettings
+	outModel.OnDestroy = data.OnDestroy
 	resp.Diagnostics.Append(resp.State.Set(ctx, outModel)...)
 }
 
 func (r *EnvironmentSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// load state/plan
 	var envId string
+	var resetUnmanagedSettings types.Bool
+	var onDestroy types.String
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("reset_unmanaged_settings"), &resetUnmanagedSettings)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("on_destroy"), &onDestroy)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// create svix client
-	svx, err := r.state.InternalClientWithEnvId(envId)
+	svx, err := r.state.InternalClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -440,7 +630,7 @@ func (r *EnvironmentSettingsResource) Read(ctx context.Context, req resource.Rea
 
 	res, err := svx.Management.EnvironmentSettings.Get(ctx)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to get environment settings")
+		logSvixReadError(ctx, resp, err, "Failed to get environment settings")
 		return
 	}
 
@@ -450,6 +640,12 @@ func (r *EnvironmentSettingsResource) Read(ctx context.Context, req resource.Rea
 	}
 
 	outModel := internalSettingsOutToTF(ctx, &resp.Diagnostics, *res, envId, otelConfigOut)
+	outModel.ResetUnmanagedSettings = resetUnmanagedSettings
+	// imported resources, and resources created before `on_destroy` existed, don't have a value yet
+	outModel.OnDestroy = onDestroy
+	if onDestroy.IsNull() {
+		outModel.OnDestroy = types.StringValue(onDestroyKeep)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, outModel)...)
 }
@@ -465,7 +661,7 @@ func (r *EnvironmentSettingsResource) Update(ctx context.Context, req resource.U
 	envId := data.EnvironmentId.ValueString()
 
 	// create svix client
-	svx, err := r.state.InternalClientWithEnvId(envId)
+	svx, err := r.state.InternalClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -496,7 +692,7 @@ func (r *EnvironmentSettingsResource) Update(ctx context.Context, req resource.U
 	// call api
 	res, err := svx.Management.EnvironmentSettings.Patch(ctx, settingsPatch)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to patch environment settings")
+		logSettingsPatchError(&resp.Diagnostics, err, data, "Failed to patch environment settings")
 		return
 	}
 
@@ -505,12 +701,154 @@ func (r *EnvironmentSettingsResource) Update(ctx context.Context, req resource.U
 	}
 
 	outModel := internalSettingsOutToTF(ctx, &resp.Diagnostics, *res, envId, otelConfigOut)
+	outModel.ResetUnmanagedSettings = data.ResetUnmanagedSettings
+	outModel.OnDestroy = data.OnDestroy
 	resp.Diagnostics.Append(resp.State.Set(ctx, outModel)...)
 }
 
 func (r *EnvironmentSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// we don't delete the env settings
-	// env delete will delete the env settings
+	// load state/plan
+	var data model.EnvironmentSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the env settings can't be deleted, env delete will delete the env settings
+	var target environmentSettingsSnapshot
+	switch data.OnDestroy.ValueString() {
+	case onDestroyReset:
+		target = defaultEnvironmentSettings
+	case onDestroySnapshotRestore:
+		snapshot, diags := req.Private.GetKey(ctx, settingsSnapshotKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if snapshot == nil {
+			resp.Diagnostics.AddWarning(
+				"Missing Environment Settings Snapshot",
+				"The settings from before terraform managed this resource were not captured (the resource was created by an older "+
+					"version of the provider), so the current settings are kept.",
+			)
+			return
+		}
+		if err := json.Unmarshal(snapshot, &target); err != nil {
+			resp.Diagnostics.AddError("Failed to read the environment settings snapshot", err.Error())
+			return
+		}
+	default:
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.InternalClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	currentOtel, _ := svx.Management.EnvironmentSettings.GetOtelConfig(ctx)
+	settingsPatch := settingsPatchFromSnapshot(target.Settings)
+	if currentOtel != nil && currentOtel.SvixManaged {
+		settingsPatch.EnableOtlp = nil
+	}
+
+	// call api
+	_, err = svx.Management.EnvironmentSettings.Patch(ctx, settingsPatch)
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		logSvixError(&resp.Diagnostics, err, "Failed to restore environment settings")
+		return
+	}
+
+	if target.OtelConfig != nil && target.OtelConfig.Url != nil && !target.OtelConfig.SvixManaged {
+		otelConfig := models.OtelConfig{
+			Url:               *target.OtelConfig.Url,
+			AdditionalHeaders: target.OtelConfig.AdditionalHeaders,
+		}
+		if err := svx.Management.EnvironmentSettings.UpdateOtelConfig(ctx, otelConfig); err != nil {
+			logSvixError(&resp.Diagnostics, err, "Failed to restore OTEL config")
+		}
+	} else {
+		deleteOtelConfig(ctx, svx, currentOtel)
+	}
+}
+
+// save the current settings of the environment in the private state
+func saveSettingsSnapshot(ctx context.Context, svx *svix_internal.InternalSvix, currentOtel *models.OtelConfigOut, private privateState) diag.Diagnostics {
+	var diags diag.Diagnostics
+	current, err := svx.Management.EnvironmentSettings.Get(ctx)
+	if err != nil {
+		logSvixError(&diags, err, "Failed to get environment settings")
+		return diags
+	}
+	snapshot := environmentSettingsSnapshot{Settings: *current}
+	if currentOtel != nil && currentOtel.Url != nil {
+		snapshot.OtelConfig = currentOtel
+	}
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		diags.AddError("Failed to save the environment settings snapshot", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, settingsSnapshotKey, b)
+}
+
+// interface of the private state of the Create and ImportState responses
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// patch every setting managed by this resource to the value it has in the snapshot
+func settingsPatchFromSnapshot(v models.SettingsInternalOut) models.SettingsInternalPatch {
+	patch := models.SettingsInternalPatch{
+		DisableEndpointOnFailure:   v.DisableEndpointOnFailure,
+		EnableChannels:             v.EnableChannels,
+		EnableEndpointMtlsConfig:   v.EnableEndpointMtlsConfig,
+		EnableEndpointOauthConfig:  v.EnableEndpointOauthConfig,
+		EnableMessageStream:        v.EnableMessageStream,
+		EnableOtlp:                 v.EnableOtlp,
+		EnableTransformations:      v.EnableTransformations,
+		EnforceHttps:               v.EnforceHttps,
+		EventCatalogPublished:      v.EventCatalogPublished,
+		RequireEndpointChannel:     v.RequireEndpointChannel,
+		RequireEndpointFilterTypes: v.RequireEndpointFilterTypes,
+		WhitelabelHeaders:          v.WhitelabelHeaders,
+		WipeSuccessfulPayload:      v.WipeSuccessfulPayload,
+
+		ReadOnly:                    v.ReadOnly,
+		EnableApplicationAlerts:     v.EnableApplicationAlerts,
+		ApplicationAlertEvents:      v.ApplicationAlertEvents,
+		EnableIntegrationManagement: v.EnableIntegrationManagement,
+		EnableMsgAtmptLog:           v.EnableMsgAtmptLog,
+		SendSvixWebhookHeaders:      v.SendSvixWebhookHeaders,
+		ShowFeatureTooltips:         v.ShowFeatureTooltips,
+		ShowUseSvixPlay:             v.ShowUseSvixPlay,
+		WebhooksAutoConfig:          v.WebhooksAutoConfig,
+	}
+	if patch.ApplicationAlertEvents == nil {
+		patch.ApplicationAlertEvents = []models.ApplicationAlertEvent{}
+	}
+	patch.ApplicationAlertsDashboardUrl.Set(v.ApplicationAlertsDashboardUrl)
+	patch.ApplicationAlertsLogoUrl.Set(v.ApplicationAlertsLogoUrl)
+	if v.RetryPolicy != nil {
+		patch.RetryPolicy.Set(&v.RetryPolicy)
+	} else {
+		patch.RetryPolicy.Set(nil)
+	}
+	patch.DisplayName.Set(v.DisplayName)
+	patch.CustomBaseFontSize.Set(v.CustomBaseFontSize)
+	patch.CustomFontFamily.Set(v.CustomFontFamily)
+	patch.CustomFontFamilyUrl.Set(v.CustomFontFamilyUrl)
+	patch.CustomLogoUrl.Set(v.CustomLogoUrl)
+	patch.CustomThemeOverride.Set(v.CustomThemeOverride)
+	patch.ColorPaletteDark.Set(v.ColorPaletteDark)
+	patch.ColorPaletteLight.Set(v.ColorPaletteLight)
+	patch.CustomStringsOverride.Set(v.CustomStringsOverride)
+	return patch
 }
 
 func customColorPaletteToTF(v models.CustomColorPalette) model.CustomColorPalette_TF {
@@ -604,6 +942,33 @@ func internalSettingsOutToTF(ctx context.Context, d *diag.Diagnostics, v models.
 		RequireEndpointFilterTypes: types.BoolPointerValue(v.RequireEndpointFilterTypes),
 		WhitelabelHeaders:          types.BoolPointerValue(v.WhitelabelHeaders),
 		WipeSuccessfulPayload:      types.BoolPointerValue(v.WipeSuccessfulPayload),
+
+		ReadOnly:                      types.BoolPointerValue(v.ReadOnly),
+		EnableApplicationAlerts:       types.BoolPointerValue(v.EnableApplicationAlerts),
+		ApplicationAlertsDashboardUrl: types.StringPointerValue(v.ApplicationAlertsDashboardUrl),
+		ApplicationAlertsLogoUrl:      types.StringPointerValue(v.ApplicationAlertsLogoUrl),
+		EnableIntegrationManagement:   types.BoolPointerValue(v.EnableIntegrationManagement),
+		EnableMsgAtmptLog:             types.BoolPointerValue(v.EnableMsgAtmptLog),
+		SendSvixWebhookHeaders:        types.BoolPointerValue(v.SendSvixWebhookHeaders),
+		ShowFeatureTooltips:           types.BoolPointerValue(v.ShowFeatureTooltips),
+		ShowUseSvixPlay:               types.BoolPointerValue(v.ShowUseSvixPlay),
+		WebhooksAutoConfig:            types.BoolPointerValue(v.WebhooksAutoConfig),
+		RetryPolicy:                   types.ListNull(types.Int64Type),
+	}
+
+	// the api omits an empty list of events
+	alertEvents := []string{}
+	for _, event := range v.ApplicationAlertEvents {
+		alertEvents = append(alertEvents, string(event))
+	}
+	alertEventsList, diags := types.ListValueFrom(ctx, types.StringType, alertEvents)
+	d.Append(diags...)
+	out.ApplicationAlertEvents = alertEventsList
+
+	if v.RetryPolicy != nil {
+		retryPolicy, diags := types.ListValueFrom(ctx, types.Int64Type, v.RetryPolicy)
+		d.Append(diags...)
+		out.RetryPolicy = retryPolicy
 	}
 
 	if otelConfig != nil && otelConfig.Url != nil {
@@ -811,3 +1176,172 @@ func (v customFontFamilyValidator) ValidateString(ctx context.Context, req valid
 		)
 	}
 }
+
+func (r *EnvironmentSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+	planResetUnmanagedSettings(ctx, req, resp)
+	warnPlanGatedSettings(ctx, req, resp)
+}
+
+// the boolean settings, which always have a value
+var boolSettings = []struct {
+	attr  string
+	value func(*model.EnvironmentSettingsResourceModel) *types.Bool
+}{
+	{"disable_endpoint_on_failure", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.DisableEndpointOnFailure }},
+	{"enable_advanced_endpoint_types", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnableMessageStream }},
+	{"enable_channels", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnableChannels }},
+	{"enable_endpoint_mtls_config", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnableEndpointMtlsConfig }},
+	{"enable_endpoint_oauth_config", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnableEndpointOauthConfig }},
+	{"enable_transformations", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnableTransformations }},
+	{"enforce_https", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnforceHttps }},
+	{"event_catalog_published", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EventCatalogPublished }},
+	{"require_endpoint_channels", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.RequireEndpointChannel }},
+	{"require_endpoint_event_types", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.RequireEndpointFilterTypes }},
+	{"whitelabel_headers", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.WhitelabelHeaders }},
+	{"delete_payload_on_successful_delivery", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.WipeSuccessfulPayload }},
+	{"read_only", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.ReadOnly }},
+	{"enable_application_alerts", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnableApplicationAlerts }},
+	{"enable_integration_management", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnableIntegrationManagement }},
+	{"enable_message_attempt_log", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.EnableMsgAtmptLog }},
+	{"send_svix_webhook_headers", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.SendSvixWebhookHeaders }},
+	{"show_feature_tooltips", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.ShowFeatureTooltips }},
+	{"show_use_svix_play", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.ShowUseSvixPlay }},
+	{"webhooks_auto_config", func(m *model.EnvironmentSettingsResourceModel) *types.Bool { return &m.WebhooksAutoConfig }},
+}
+
+// with `reset_unmanaged_settings`, plan the computed settings that are missing from the configuration at their Svix default
+// (instead of keeping their current value), so the plan shows them being reset
+func planResetUnmanagedSettings(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var reset types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, rp("reset_unmanaged_settings"), &reset)...)
+	if resp.Diagnostics.HasError() || !reset.ValueBool() {
+		return
+	}
+
+	var config model.EnvironmentSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.ApplicationAlertEvents.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("application_alert_events"), []string{})...)
+	}
+	if config.ApplicationAlertsDashboardUrl.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("application_alerts_dashboard_url"), types.StringNull())...)
+	}
+	if config.ApplicationAlertsLogoUrl.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("application_alerts_logo_url"), types.StringNull())...)
+	}
+	if config.RetryPolicy.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("retry_schedule"), types.ListNull(types.Int64Type))...)
+	}
+
+	defaults := internalSettingsOutToTF(ctx, &resp.Diagnostics, defaultEnvironmentSettings.Settings, "", nil)
+	for _, setting := range boolSettings {
+		if setting.value(&config).IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp(setting.attr), *setting.value(&defaults))...)
+		}
+	}
+
+	var whitelabelSettings types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, rp("whitelabel_settings"), &whitelabelSettings)...)
+	if resp.Diagnostics.HasError() || whitelabelSettings.IsNull() || whitelabelSettings.IsUnknown() {
+		return
+	}
+	displayNamePath := rp("whitelabel_settings").AtName("display_name")
+	var displayName types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, displayNamePath, &displayName)...)
+	if displayName.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, displayNamePath, types.StringNull())...)
+	}
+}
+
+// settings that are only available on some plans
+var planGatedSettings = []struct {
+	attr    string
+	plan    string
+	enabled func(model.EnvironmentSettingsResourceModel) bool
+}{
+	{"enable_advanced_endpoint_types", "Pro or Enterprise", func(m model.EnvironmentSettingsResourceModel) bool { return m.EnableMessageStream.ValueBool() }},
+	{"enable_endpoint_mtls_config", "Enterprise", func(m model.EnvironmentSettingsResourceModel) bool { return m.EnableEndpointMtlsConfig.ValueBool() }},
+	{"enable_endpoint_oauth_config", "Enterprise", func(m model.EnvironmentSettingsResourceModel) bool { return m.EnableEndpointOauthConfig.ValueBool() }},
+	{"whitelabel_headers", "Pro or Enterprise", func(m model.EnvironmentSettingsResourceModel) bool { return m.WhitelabelHeaders.ValueBool() }},
+	{"delete_payload_on_successful_delivery", "Pro or Enterprise", func(m model.EnvironmentSettingsResourceModel) bool { return m.WipeSuccessfulPayload.ValueBool() }},
+	{"otel_config", "Enterprise", func(m model.EnvironmentSettingsResourceModel) bool {
+		return !m.OtelConfig.IsNull() && !m.OtelConfig.IsUnknown()
+	}},
+}
+
+// the plan-gated settings enabled in `m`, except those already enabled in `current` (if not nil), as a markdown list
+func enabledPlanGatedSettings(m model.EnvironmentSettingsResourceModel, current *model.EnvironmentSettingsResourceModel) []string {
+	var enabled []string
+	for _, setting := range planGatedSettings {
+		if !setting.enabled(m) || (current != nil && setting.enabled(*current)) {
+			continue
+		}
+		enabled = append(enabled, fmt.Sprintf("- `%s` requires the %s plan", setting.attr, setting.plan))
+	}
+	return enabled
+}
+
+// remind that the newly enabled settings are only available on some plans, so a 403 during the apply doesn't come as a surprise.
+//
+// This is a static reminder, not a check: the api doesn't expose the organization's plan or entitlements, so the warning
+// is shown whatever the plan is. Settings that are already enabled are assumed to be available
+func warnPlanGatedSettings(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan model.EnvironmentSettingsResourceModel
+	var state *model.EnvironmentSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		state = &model.EnvironmentSettingsResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	enabled := enabledPlanGatedSettings(plan, state)
+	if len(enabled) == 0 {
+		return
+	}
+	resp.Diagnostics.AddWarning(
+		"Settings May Require A Paid Plan",
+		fmt.Sprintf("These settings are only available on some plans:\n%s\n\n"+
+			"The Svix API doesn't expose the organization's plan, so this warning is shown whatever the plan is. "+
+			"If the organization isn't on the required plan, the apply will fail with a 403 error.",
+			strings.Join(enabled, "\n")),
+	)
+}
+
+// like `logSvixError`, but a 403 lists the enabled settings that require a paid plan, the usual cause of the error
+func logSettingsPatchError(d *diag.Diagnostics, err error, data model.EnvironmentSettingsResourceModel, msg string) {
+	status, body, ok := svixErrorStatusAndBody(err)
+	enabled := enabledPlanGatedSettings(data, nil)
+	if !ok || status != http.StatusForbidden || len(enabled) == 0 {
+		logSvixError(d, err, msg)
+		return
+	}
+	d.AddError(msg, fmt.Sprintf("Svix rejected the settings. Check that the organization's plan includes the enabled settings "+
+		"that are only available on some plans:\n%s\n\nstatus code: %d %s\n\nbody: %s",
+		strings.Join(enabled, "\n"), status, http.StatusText(status), string(body)))
+}
+
+func (r *EnvironmentSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the settings before the import are the ones restored by `on_destroy = "snapshot_restore"`
+	svx, err := r.state.InternalClientWithEnvId(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+	currentOtel, _ := svx.Management.EnvironmentSettings.GetOtelConfig(ctx)
+	resp.Diagnostics.Append(saveSettingsSnapshot(ctx, svx, currentOtel, resp.Private)...)
+}
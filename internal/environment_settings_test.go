@@ -0,0 +1,393 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/svix/svix-webhooks/go/models"
+	"github.com/svix/terraform-provider-svix/internal/model"
+)
+
+// settings returned by the api that the resource doesn't manage
+var unmappedSettings = []string{"customColor", "enableOtlp", "showSvixBrandFooter", "whitelabelLogo"}
+
+// every setting managed by the resource, set to a non default value
+func allSettingsOut() models.SettingsInternalOut {
+	palette := func(prefix string) *models.CustomColorPalette {
+		return &models.CustomColorPalette{
+			BackgroundHover:     ptr(prefix + "1"),
+			BackgroundPrimary:   ptr(prefix + "2"),
+			BackgroundSecondary: ptr(prefix + "3"),
+			ButtonPrimary:       ptr(prefix + "4"),
+			InteractiveAccent:   ptr(prefix + "5"),
+			NavigationAccent:    ptr(prefix + "6"),
+			Primary:             ptr(prefix + "7"),
+			TextDanger:          ptr(prefix + "8"),
+			TextPrimary:         ptr(prefix + "9"),
+		}
+	}
+	return models.SettingsInternalOut{
+		ApplicationAlertEvents:        []models.ApplicationAlertEvent{models.APPLICATIONALERTEVENT_ENDPOINT_DISABLED, models.APPLICATIONALERTEVENT_STREAM_SINK_DISABLED},
+		ApplicationAlertsDashboardUrl: ptr("https://example.com/dashboard"),
+		ApplicationAlertsLogoUrl:      ptr("https://example.com/logo.png"),
+		ColorPaletteDark:              palette("#00000"),
+		ColorPaletteLight:             palette("#fffff"),
+		CustomBaseFontSize:            ptr(int64(14)),
+		CustomFontFamily:              ptr("Custom"),
+		CustomFontFamilyUrl:           ptr("https://example.com/font.woff2"),
+		CustomLogoUrl:                 ptr("https://example.com/portal-logo.png"),
+		CustomStringsOverride: &models.CustomStringsOverride{
+			ChannelsHelp: ptr("Help"),
+			ChannelsMany: ptr("Topics"),
+			ChannelsOne:  ptr("Topic"),
+		},
+		CustomThemeOverride: &models.CustomThemeOverride{
+			BorderRadius: &models.BorderRadiusConfig{
+				Button: ptr(models.BORDERRADIUSENUM_FULL),
+				Card:   ptr(models.BORDERRADIUSENUM_LG),
+				Input:  ptr(models.BORDERRADIUSENUM_NONE),
+			},
+		},
+		DisableEndpointOnFailure:    ptr(false),
+		DisplayName:                 ptr("Acme"),
+		EnableApplicationAlerts:     ptr(true),
+		EnableChannels:              ptr(true),
+		EnableEndpointMtlsConfig:    ptr(true),
+		EnableEndpointOauthConfig:   ptr(false),
+		EnableIntegrationManagement: ptr(true),
+		EnableMessageStream:         ptr(true),
+		EnableMsgAtmptLog:           ptr(false),
+		EnableTransformations:       ptr(true),
+		EnforceHttps:                ptr(false),
+		EventCatalogPublished:       ptr(true),
+		ReadOnly:                    ptr(true),
+		RequireEndpointChannel:      ptr(true),
+		RequireEndpointFilterTypes:  ptr(false),
+		RetryPolicy:                 []int32{5, 300, 3600},
+		SendSvixWebhookHeaders:      ptr(true),
+		ShowFeatureTooltips:         ptr(false),
+		ShowUseSvixPlay:             ptr(false),
+		WebhooksAutoConfig:          ptr(true),
+		WhitelabelHeaders:           ptr(true),
+		WipeSuccessfulPayload:       ptr(true),
+	}
+}
+
+// the json sent or received by the api, decoded so it can be compared
+func settingsJSON(t *testing.T, v any) map[string]any {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// a new setting added to the sdk must be mapped by the resource, or explicitly listed in `unmappedSettings`
+func TestSettingsInternalOutFieldsAreMapped(t *testing.T) {
+	mapped := settingsJSON(t, allSettingsOut())
+	typ := reflect.TypeOf(models.SettingsInternalOut{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if _, ok := mapped[name]; !ok && !slices.Contains(unmappedSettings, name) {
+			t.Errorf("setting %q isn't covered by allSettingsOut or unmappedSettings", name)
+		}
+	}
+}
+
+// SettingsInternalOut -> terraform model -> SettingsInternalPatch
+func TestEnvironmentSettingsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	nullWhitelabel := map[string]any{
+		"displayName":           nil,
+		"customBaseFontSize":    nil,
+		"customFontFamily":      nil,
+		"customFontFamilyUrl":   nil,
+		"customLogoUrl":         nil,
+		"customThemeOverride":   nil,
+		"colorPaletteDark":      nil,
+		"colorPaletteLight":     nil,
+		"customStringsOverride": nil,
+	}
+	// unset settings that are always sent, so they are cleared
+	unsetDefaults := map[string]any{
+		"applicationAlertEvents":        []any{},
+		"applicationAlertsDashboardUrl": nil,
+		"applicationAlertsLogoUrl":      nil,
+		"retryPolicy":                   nil,
+	}
+	merge := func(maps ...map[string]any) map[string]any {
+		out := map[string]any{}
+		for _, m := range maps {
+			for k, v := range m {
+				out[k] = v
+			}
+		}
+		return out
+	}
+
+	all := allSettingsOut()
+	allPatch := settingsJSON(t, all)
+	for _, name := range unmappedSettings {
+		delete(allPatch, name)
+	}
+
+	tests := []struct {
+		name  string
+		out   models.SettingsInternalOut
+		reset bool
+		want  map[string]any
+	}{
+		{
+			name: "every setting",
+			out:  all,
+			want: allPatch,
+		},
+		{
+			name:  "every setting with reset_unmanaged_settings",
+			out:   all,
+			reset: true,
+			want:  allPatch,
+		},
+		{
+			name: "unset settings",
+			out:  models.SettingsInternalOut{},
+			want: unsetDefaults,
+		},
+		{
+			name:  "unset settings with reset_unmanaged_settings",
+			out:   models.SettingsInternalOut{},
+			reset: true,
+			want:  merge(unsetDefaults, nullWhitelabel),
+		},
+		{
+			name: "unmanaged settings are ignored",
+			out: models.SettingsInternalOut{
+				CustomColor:         ptr("#ff0000"),
+				EnableOtlp:          ptr(true),
+				ShowSvixBrandFooter: ptr(true),
+				WhitelabelLogo:      ptr("https://example.com/logo.png"),
+			},
+			want: unsetDefaults,
+		},
+		{
+			name: "empty retry_schedule",
+			out:  models.SettingsInternalOut{RetryPolicy: []int32{}},
+			want: merge(unsetDefaults, map[string]any{"retryPolicy": []any{}}),
+		},
+		{
+			name: "partial whitelabel_settings",
+			out:  models.SettingsInternalOut{DisplayName: ptr("Acme")},
+			// the null attributes of whitelabel_settings are cleared, the null nested objects are left as is
+			want: merge(unsetDefaults, map[string]any{
+				"displayName":         "Acme",
+				"customBaseFontSize":  nil,
+				"customFontFamily":    nil,
+				"customFontFamilyUrl": nil,
+				"customLogoUrl":       nil,
+			}),
+		},
+		{
+			name:  "partial whitelabel_settings with reset_unmanaged_settings",
+			out:   models.SettingsInternalOut{DisplayName: ptr("Acme")},
+			reset: true,
+			want:  merge(unsetDefaults, nullWhitelabel, map[string]any{"displayName": "Acme"}),
+		},
+		{
+			name: "partial nested whitelabel_settings",
+			out: models.SettingsInternalOut{
+				ColorPaletteDark:      &models.CustomColorPalette{Primary: ptr("#000000")},
+				CustomStringsOverride: &models.CustomStringsOverride{ChannelsOne: ptr("Topic")},
+				CustomThemeOverride:   &models.CustomThemeOverride{BorderRadius: &models.BorderRadiusConfig{Card: ptr(models.BORDERRADIUSENUM_SM)}},
+			},
+			want: merge(unsetDefaults, map[string]any{
+				"displayName":           nil,
+				"customBaseFontSize":    nil,
+				"customFontFamily":      nil,
+				"customFontFamilyUrl":   nil,
+				"customLogoUrl":         nil,
+				"colorPaletteDark":      map[string]any{"primary": "#000000"},
+				"customStringsOverride": map[string]any{"channelsOne": "Topic"},
+				"customThemeOverride":   map[string]any{"borderRadius": map[string]any{"card": "sm"}},
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d diag.Diagnostics
+			tf := internalSettingsOutToTF(ctx, &d, tt.out, "env_1", nil)
+			tf.ResetUnmanagedSettings = types.BoolValue(tt.reset)
+			patch := model.PatchSettingsInternalPatchWithPlan(ctx, &d, tf)
+			if d.HasError() {
+				t.Fatalf("unexpected errors: %v", d)
+			}
+
+			got := settingsJSON(t, patch)
+			if !reflect.DeepEqual(got, tt.want) {
+				gotJSON, _ := json.MarshalIndent(got, "", "  ")
+				wantJSON, _ := json.MarshalIndent(tt.want, "", "  ")
+				t.Errorf("patch = %s\n\nwant %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestEnvironmentSettingsRoundTripOtelConfig(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		otel        *models.OtelConfigOut
+		wantNull    bool
+		wantHeaders map[string]string
+	}{
+		{name: "not configured", otel: nil, wantNull: true},
+		{name: "without url", otel: &models.OtelConfigOut{}, wantNull: true},
+		{name: "without headers", otel: &models.OtelConfigOut{Url: ptr("https://otel.example.com")}},
+		{name: "empty headers", otel: &models.OtelConfigOut{Url: ptr("https://otel.example.com"), AdditionalHeaders: &map[string]string{}}},
+		{
+			name:        "with headers",
+			otel:        &models.OtelConfigOut{Url: ptr("https://otel.example.com"), AdditionalHeaders: &map[string]string{"x-api-key": "key"}},
+			wantHeaders: map[string]string{"x-api-key": "key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d diag.Diagnostics
+			tf := internalSettingsOutToTF(ctx, &d, models.SettingsInternalOut{}, "env_1", tt.otel)
+			if d.HasError() {
+				t.Fatalf("unexpected errors: %v", d)
+			}
+			if tf.OtelConfig.IsNull() != tt.wantNull {
+				t.Fatalf("otel_config null = %v, want %v", tf.OtelConfig.IsNull(), tt.wantNull)
+			}
+			if tt.wantNull {
+				return
+			}
+
+			var otel model.OtelConfig_TF
+			d.Append(tf.OtelConfig.As(ctx, &otel, basetypes.ObjectAsOptions{})...)
+			if otel.Url.ValueString() != *tt.otel.Url {
+				t.Errorf("url = %s, want %s", otel.Url, *tt.otel.Url)
+			}
+			// empty headers are read as null, so `additional_headers = {}` isn't required in the config
+			if tt.wantHeaders == nil {
+				if !otel.AdditionalHeaders.IsNull() {
+					t.Errorf("additional_headers = %s, want null", otel.AdditionalHeaders)
+				}
+				return
+			}
+			headers := map[string]string{}
+			d.Append(otel.AdditionalHeaders.ElementsAs(ctx, &headers, false)...)
+			if !reflect.DeepEqual(headers, tt.wantHeaders) {
+				t.Errorf("additional_headers = %v, want %v", headers, tt.wantHeaders)
+			}
+		})
+	}
+}
+
+// a new boolean setting must be listed in `boolSettings`, so `reset_unmanaged_settings` resets it
+func TestBoolSettingsAreListed(t *testing.T) {
+	var listed []string
+	for _, setting := range boolSettings {
+		listed = append(listed, setting.attr)
+	}
+	typ := reflect.TypeOf(model.EnvironmentSettingsResourceModel{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := field.Tag.Get("tfsdk")
+		if field.Type != reflect.TypeOf(types.Bool{}) || name == "reset_unmanaged_settings" {
+			continue
+		}
+		if !slices.Contains(listed, name) {
+			t.Errorf("boolean setting %q isn't listed in boolSettings", name)
+		}
+	}
+}
+
+func TestPlanResetUnmanagedSettings(t *testing.T) {
+	ctx := context.Background()
+	r := &EnvironmentSettingsResource{}
+	var s resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &s)
+
+	boolVal := func(v bool) tftypes.Value { return tftypes.NewValue(tftypes.Bool, v) }
+	// every boolean setting is currently the opposite of its default
+	defaults := internalSettingsOutToTF(ctx, &diag.Diagnostics{}, defaultEnvironmentSettings.Settings, "", nil)
+	state := map[string]tftypes.Value{"environment_id": tftypes.NewValue(tftypes.String, "env_1")}
+	for _, setting := range boolSettings {
+		state[setting.attr] = boolVal(!setting.value(&defaults).ValueBool())
+	}
+
+	for _, reset := range []bool{false, true} {
+		t.Run(fmt.Sprintf("reset_unmanaged_settings=%v", reset), func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"environment_id":           tftypes.NewValue(tftypes.String, "env_1"),
+				"reset_unmanaged_settings": boolVal(reset),
+				"enforce_https":            boolVal(false),
+			}
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s.Schema, Raw: testObjectValue(t, s, config)},
+				State:  tfsdk.State{Schema: s.Schema, Raw: testObjectValue(t, s, state)},
+				Plan:   tfsdk.Plan{Schema: s.Schema, Raw: testObjectValue(t, s, state)},
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			planResetUnmanagedSettings(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			var plan model.EnvironmentSettingsResourceModel
+			resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+			for _, setting := range boolSettings {
+				// the configured setting is left to the plan, the others keep their state or are reset
+				want := !setting.value(&defaults).ValueBool()
+				if reset && setting.attr != "enforce_https" {
+					want = setting.value(&defaults).ValueBool()
+				}
+				if got := setting.value(&plan).ValueBool(); got != want {
+					t.Errorf("planned %s = %v, want %v", setting.attr, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLogSettingsPatchError(t *testing.T) {
+	forbidden := &managementApiError{status: 403, body: []byte(`{"code":"forbidden"}`)}
+	withMtls := model.EnvironmentSettingsResourceModel{EnableEndpointMtlsConfig: types.BoolValue(true)}
+	tests := []struct {
+		name      string
+		err       error
+		data      model.EnvironmentSettingsResourceModel
+		wantInErr string
+	}{
+		{name: "403 with a plan-gated setting", err: forbidden, data: withMtls, wantInErr: "- `enable_endpoint_mtls_config` requires the Enterprise plan"},
+		{name: "403 without plan-gated settings", err: forbidden, wantInErr: "The API token is not allowed to do this"},
+		{name: "other error", err: &managementApiError{status: 500, body: []byte("oops")}, data: withMtls, wantInErr: "status code: 500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d diag.Diagnostics
+			logSettingsPatchError(&d, tt.err, tt.data, "Failed to patch environment settings")
+			if d.ErrorsCount() != 1 || !strings.Contains(d.Errors()[0].Detail(), tt.wantInErr) {
+				t.Errorf("diagnostics = %v, want an error containing %q", d, tt.wantInErr)
+			}
+		})
+	}
+}
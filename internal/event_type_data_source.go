@@ -0,0 +1,265 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	svix "github.com/svix/svix-webhooks/go"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+var _ datasource.DataSource = &EventTypeDataSource{}
+var _ datasource.DataSource = &EventTypesDataSource{}
+
+func NewEventTypeDataSource() datasource.DataSource {
+	return &EventTypeDataSource{}
+}
+
+func NewEventTypesDataSource() datasource.DataSource {
+	return &EventTypesDataSource{}
+}
+
+type EventTypeDataSource struct {
+	state *appState
+}
+
+type EventTypesDataSource struct {
+	state *appState
+}
+
+type EventTypesDataSourceModel struct {
+	EnvironmentId   types.String             `tfsdk:"environment_id"`
+	GroupName       types.String             `tfsdk:"group_name"`
+	FeatureFlag     types.String             `tfsdk:"feature_flag"`
+	IncludeArchived types.Bool               `tfsdk:"include_archived"`
+	EventTypes      []EventTypeResourceModel `tfsdk:"event_types"`
+}
+
+func eventTypeDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"environment_id": schema.StringAttribute{Computed: true},
+		"archived":       schema.BoolAttribute{Computed: true},
+		"created_at":     schema.StringAttribute{Computed: true, CustomType: timetypes.RFC3339Type{}},
+		"deprecated":     schema.BoolAttribute{Computed: true},
+		"description":    schema.StringAttribute{Computed: true},
+		"feature_flag":   schema.StringAttribute{Computed: true},
+		"group_name":     schema.StringAttribute{Computed: true},
+		"name":           schema.StringAttribute{Computed: true},
+		"schemas":        schema.StringAttribute{Computed: true, CustomType: jsontypes.NormalizedType{}},
+		"updated_at":     schema.StringAttribute{Computed: true, CustomType: timetypes.RFC3339Type{}},
+	}
+}
+
+func eventTypeOutToModel(d *diag.Diagnostics, envId string, res models.EventTypeOut) EventTypeResourceModel {
+	var schemasJson *string
+	if res.Schemas != nil {
+		jsonV, err := json.Marshal(res.Schemas)
+		if err != nil {
+			d.AddError("Failed to marshal a map[string]any to a string", err.Error())
+		} else {
+			schemasJson = ptr(string(jsonV))
+		}
+	}
+
+	return EventTypeResourceModel{
+		EnvironmentId: types.StringValue(envId),
+		Archived:      types.BoolPointerValue(res.Archived),
+		CreatedAt:     timetypes.NewRFC3339TimeValue(res.CreatedAt),
+		Deprecated:    types.BoolValue(res.Deprecated),
+		Description:   types.StringValue(res.Description),
+		FeatureFlag:   types.StringPointerValue(res.FeatureFlag),
+		GroupName:     types.StringPointerValue(res.GroupName),
+		Name:          types.StringValue(res.Name),
+		Schemas:       jsontypes.NewNormalizedPointerValue(schemasJson),
+		UpdatedAt:     timetypes.NewRFC3339TimeValue(res.UpdatedAt),
+	}
+}
+
+func (d *EventTypeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "svix_event_type"
+}
+
+func (d *EventTypeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.state = state
+}
+
+func (d *EventTypeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := eventTypeDataSourceAttributes()
+	attrs["environment_id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The Id of the environment the event type belongs to, defaults to the provider's `environment_id`",
+	}
+	attrs["name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The name of the event type",
+	}
+	resp.Schema = schema.Schema{
+		Description: "Look up a single event type by name",
+		Attributes:  attrs,
+	}
+}
+
+func (d *EventTypeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// load config
+	var data EventTypeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	envId := d.state.environmentIdOrDefault(ctx, &resp.Diagnostics, data.EnvironmentId)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.EnvironmentId = types.StringValue(envId)
+
+	// create svix client
+	svx, err := d.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	res, err := svx.EventType.Get(ctx, data.Name.ValueString())
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to read event type")
+		return
+	}
+
+	// save state
+	out := eventTypeOutToModel(&resp.Diagnostics, envId, *res)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &out)...)
+}
+
+func (d *EventTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "svix_event_types"
+}
+
+func (d *EventTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.state = state
+}
+
+func (d *EventTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the event types of an environment",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The Id of the environment to list the event types of, defaults to the provider's `environment_id`",
+			},
+			"group_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include event types in this group",
+			},
+			"feature_flag": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include event types gated behind this feature flag",
+			},
+			"include_archived": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Include archived event types, defaults to `false`",
+			},
+			"event_types": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: eventTypeDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *EventTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// load config
+	var data EventTypesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	envId := d.state.environmentIdOrDefault(ctx, &resp.Diagnostics, data.EnvironmentId)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.EnvironmentId = types.StringValue(envId)
+
+	// create svix client
+	svx, err := d.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	var eventTypes []models.EventTypeOut
+	opts := svix.EventTypeListOptions{
+		Limit:           ptr(uint64(250)),
+		IncludeArchived: boolOrNil(data.IncludeArchived),
+		WithContent:     ptr(true),
+	}
+	for {
+		res, err := svx.EventType.List(ctx, &opts)
+		if err != nil {
+			logSvixError(&resp.Diagnostics, err, "Failed to list event types")
+			return
+		}
+		eventTypes = append(eventTypes, res.Data...)
+		if res.Done || res.Iterator == nil {
+			break
+		}
+		opts.Iterator = res.Iterator
+	}
+
+	// save state
+	data.EventTypes = []EventTypeResourceModel{}
+	for _, et := range eventTypes {
+		if !data.GroupName.IsNull() && (et.GroupName == nil || *et.GroupName != data.GroupName.ValueString()) {
+			continue
+		}
+		if !data.FeatureFlag.IsNull() {
+			flag := data.FeatureFlag.ValueString()
+			if (et.FeatureFlag == nil || *et.FeatureFlag != flag) && !slices.Contains(et.FeatureFlags, flag) {
+				continue
+			}
+		}
+		data.EventTypes = append(data.EventTypes, eventTypeOutToModel(&resp.Diagnostics, envId, et))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -16,9 +16,11 @@ import (
 )
 
 var _ resource.Resource = &EventTypeOpenapiImportResource{}
+var _ resource.ResourceWithImportState = &EventTypeOpenapiImportResource{}
+var _ resource.ResourceWithModifyPlan = &EventTypeOpenapiImportResource{}
 
 type EventTypeOpenapiImportResource struct {
-	state appState
+	state *appState
 }
 
 type EventTypeOpenapiImportResourceModel struct {
@@ -36,11 +38,11 @@ func (r *EventTypeOpenapiImportResource) Configure(ctx context.Context, req reso
 	if req.ProviderData == nil {
 		return
 	}
-	state, ok := req.ProviderData.(appState)
+	state, ok := req.ProviderData.(*appState)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -59,10 +61,11 @@ func (r *EventTypeOpenapiImportResource) Schema(ctx context.Context, req resourc
 			"The OpenAPI spec is specified in the `raw_spec` field a YAML or JSON string",
 		Attributes: map[string]schema.Attribute{
 			"environment_id": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: ENV_ID_DESC,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"replace_all": schema.BoolAttribute{
@@ -97,7 +100,7 @@ func (r *EventTypeOpenapiImportResource) Create(ctx context.Context, req resourc
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -156,7 +159,7 @@ func (r *EventTypeOpenapiImportResource) Update(ctx context.Context, req resourc
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -199,7 +202,7 @@ func (r *EventTypeOpenapiImportResource) Delete(ctx context.Context, req resourc
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -207,10 +210,23 @@ func (r *EventTypeOpenapiImportResource) Delete(ctx context.Context, req resourc
 
 	for _, eventTypeName := range eventTypesToDelete {
 		err = svx.EventType.Delete(ctx, eventTypeName, nil)
-		if err != nil {
+		if err != nil && !isNotFoundError(err) {
 			logSvixError(&resp.Diagnostics, err, fmt.Sprintf("Failed to delete event type %s", eventTypeName))
 			return
 		}
 
 	}
 }
+
+func (r *EventTypeOpenapiImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+}
+
+// the spec and the event types it created aren't stored by svix, so an import can't be read back without a diff
+func (r *EventTypeOpenapiImportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.AddError(
+		"Resource Import Not Supported",
+		"svix_event_type_openapi_import can't be imported: Svix doesn't store the imported OpenAPI spec or which event types it created. "+
+			"Add the resource to the configuration and apply it instead, importing the same spec again only updates the existing event types.",
+	)
+}
@@ -21,13 +21,15 @@ import (
 )
 
 var _ resource.Resource = &EventTypeResource{}
+var _ resource.ResourceWithImportState = &EventTypeResource{}
+var _ resource.ResourceWithModifyPlan = &EventTypeResource{}
 
 func NewEventTypeResource() resource.Resource {
 	return &EventTypeResource{}
 }
 
 type EventTypeResource struct {
-	state appState
+	state *appState
 }
 
 type EventTypeResourceModel struct {
@@ -51,10 +53,11 @@ func (r *EventTypeResource) Schema(ctx context.Context, req resource.SchemaReque
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"environment_id": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: ENV_ID_DESC,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"archived": schema.BoolAttribute{Computed: true, Optional: true, Default: booldefault.StaticBool(false)},
@@ -98,11 +101,11 @@ func (r *EventTypeResource) Configure(ctx context.Context, req resource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	state, ok := req.ProviderData.(appState)
+	state, ok := req.ProviderData.(*appState)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -120,7 +123,7 @@ func (r *EventTypeResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -180,7 +183,7 @@ func (r *EventTypeResource) Create(ctx context.Context, req resource.CreateReque
 	setCreateState(ctx, resp, rp("group_name"), res.GroupName)
 	setCreateState(ctx, resp, rp("name"), res.Name)
 	setCreateState(ctx, resp, rp("schemas"), jsontypes.NewNormalizedPointerValue(schemasJson))
-	setCreateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setCreateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
 }
 
 func (r *EventTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -190,7 +193,7 @@ func (r *EventTypeResource) Read(ctx context.Context, req resource.ReadRequest,
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -199,7 +202,7 @@ func (r *EventTypeResource) Read(ctx context.Context, req resource.ReadRequest,
 	// call api
 	res, err := svx.EventType.Get(ctx, name)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to read event type")
+		logSvixReadError(ctx, resp, err, "Failed to read event type")
 		return
 	}
 
@@ -227,7 +230,7 @@ func (r *EventTypeResource) Read(ctx context.Context, req resource.ReadRequest,
 	setReadState(ctx, resp, rp("group_name"), res.GroupName)
 	setReadState(ctx, resp, rp("name"), res.Name)
 	setReadState(ctx, resp, rp("schemas"), jsontypes.NewNormalizedPointerValue(schemasJson))
-	setReadState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.CreatedAt))
+	setReadState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
 }
 
 func (r *EventTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -238,7 +241,7 @@ func (r *EventTypeResource) Update(ctx context.Context, req resource.UpdateReque
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -296,7 +299,7 @@ func (r *EventTypeResource) Delete(ctx context.Context, req resource.DeleteReque
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -306,8 +309,16 @@ func (r *EventTypeResource) Delete(ctx context.Context, req resource.DeleteReque
 		Expunge: ptr(false),
 	})
 
-	if err != nil {
+	if err != nil && !isNotFoundError(err) {
 		logSvixError(&resp.Diagnostics, err, "Failed to delete event type")
 		return
 	}
 }
+
+func (r *EventTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+}
+
+func (r *EventTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "name")
+}
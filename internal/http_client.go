@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+	"unsafe"
+
+	svix_internal "github.com/svix/svix-webhooks/go/internalapi"
+)
+
+// retry/timeout settings of the http client shared by all svix clients
+type retryConfig struct {
+	maxRetries     int
+	minBackoff     time.Duration
+	maxBackoff     time.Duration
+	requestTimeout time.Duration
+}
+
+var defaultRetryConfig = retryConfig{
+	maxRetries:     3,
+	minBackoff:     time.Second,
+	maxBackoff:     30 * time.Second,
+	requestTimeout: 60 * time.Second,
+}
+
+// create the http client used by every svix client, requests are retried with an exponential backoff by `retryTransport`
+// and every attempt is logged by `loggingTransport`
+func newHttpClient(cfg retryConfig) *http.Client {
+	// same transport settings as the svix sdk (HTTP/2.0 is disabled)
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.ForceAttemptHTTP2 = false
+	tr.TLSClientConfig = new(tls.Config)
+	tr.TLSNextProto = make(map[string]func(authority string, c *tls.Conn) http.RoundTripper)
+	// all the requests go to the same host, so keep enough idle connections around for terraform's parallelism
+	tr.MaxIdleConns = 100
+	tr.MaxIdleConnsPerHost = 32
+
+	return &http.Client{
+		Transport: &retryTransport{next: &loggingTransport{next: tr}, cfg: cfg},
+	}
+}
+
+// retries requests that failed with a network error, a 429 or a 5xx status code.
+//
+// The request is resent as is, so retries of a create keep the same `idempotency-key` header and are safe
+type retryTransport struct {
+	next http.RoundTripper
+	cfg  retryConfig
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a body that can't be read again can't be retried
+	maxRetries := t.cfg.maxRetries
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Header.Set("svix-retry-count", strconv.Itoa(attempt))
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		res, err := t.roundTripWithTimeout(attemptReq)
+		if attempt >= maxRetries || !shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		if res != nil {
+			// drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send a single attempt, limited by `request_timeout`
+func (t *retryTransport) roundTripWithTimeout(req *http.Request) (*http.Response, error) {
+	if t.cfg.requestTimeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.cfg.requestTimeout)
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// the timeout also applies to reading the body, so only cancel once it is closed
+	res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		// don't retry if terraform canceled the operation
+		return req.Context().Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+}
+
+// exponential backoff with jitter, a `Retry-After` header sent by the server takes precedence
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := t.cfg.minBackoff << attempt
+	if wait <= 0 || wait > t.cfg.maxBackoff {
+		wait = t.cfg.maxBackoff
+	}
+	// up to 25% of jitter, so parallel requests don't retry all at once
+	if jitter := int64(wait / 4); jitter > 0 {
+		wait = wait - time.Duration(jitter) + time.Duration(rand.Int64N(jitter))
+	}
+	return max(wait, t.cfg.minBackoff)
+}
+
+// parse a `Retry-After` header, which is either a number of seconds or an http date
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// The internal svix client doesn't accept a custom http client (`svix_internal.New` has no options), so it is set on
+// the (unexported) client shared by all the internal api groups. The sdk retries are disabled since `retryTransport`
+// handles them.
+//
+// An error is returned instead of panicking if an sdk upgrade changes the client, `TestSetInternalHttpClient` checks
+// that the requests of every api group still go through `client`
+func setInternalHttpClient(svx *svix_internal.InternalSvix, client *http.Client) error {
+	if client == nil {
+		return nil
+	}
+	errUnsupported := fmt.Errorf("unable to set the http client of the internal svix client, the svix sdk isn't supported by this version of the provider")
+
+	clientField := reflect.ValueOf(svx.Management.Environment).Elem().FieldByName("client")
+	if !clientField.IsValid() || clientField.Kind() != reflect.Pointer || clientField.IsNil() || clientField.Type().Elem().Kind() != reflect.Struct {
+		return errUnsupported
+	}
+	httpClient := reflect.NewAt(clientField.Type(), unsafe.Pointer(clientField.UnsafeAddr())).Elem().Elem()
+	httpClientField := httpClient.FieldByName("HTTPClient")
+	retryScheduleField := httpClient.FieldByName("RetrySchedule")
+	if !httpClientField.CanSet() || httpClientField.Type() != reflect.TypeOf(client) ||
+		!retryScheduleField.CanSet() || retryScheduleField.Type() != reflect.TypeOf([]time.Duration{}) {
+		return errUnsupported
+	}
+	httpClientField.Set(reflect.ValueOf(client))
+	retryScheduleField.Set(reflect.ValueOf([]time.Duration{}))
+	return nil
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	svix_internal "github.com/svix/svix-webhooks/go/internalapi"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+type countingTransport struct {
+	count atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// `setInternalHttpClient` relies on unexported fields of the internal svix client, this fails if an sdk upgrade changes them
+func TestSetInternalHttpClient(t *testing.T) {
+	var serverCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverCount.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svx, err := svix_internal.New("testsk_token", serverUrl, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &countingTransport{}
+	if err := setInternalHttpClient(svx, &http.Client{Transport: transport}); err != nil {
+		t.Fatal(err)
+	}
+
+	// every api group used by the provider
+	ctx := context.Background()
+	calls := map[string]func() error{
+		"Management.Environment": func() error {
+			_, err := svx.Management.Environment.Get(ctx, "env_1")
+			return err
+		},
+		"Management.EnvironmentSettings": func() error {
+			_, err := svx.Management.EnvironmentSettings.Get(ctx)
+			return err
+		},
+		"Management.Authentication": func() error {
+			return svx.Management.Authentication.ExpireApiToken(ctx, "env_1", "key_1", models.ApiTokenExpireIn{}, nil)
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			transport.count.Store(0)
+			serverCount.Store(0)
+			if err := call(); err == nil {
+				t.Fatal("expected the request to fail")
+			}
+			if got := transport.count.Load(); got != 1 {
+				t.Errorf("requests sent through the http client = %d, want 1", got)
+			}
+			// the retries of the sdk are disabled, `retryTransport` handles them
+			if got := serverCount.Load(); got != 1 {
+				t.Errorf("requests received by the server = %d, want 1", got)
+			}
+		})
+	}
+}
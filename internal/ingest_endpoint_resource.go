@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
@@ -23,24 +24,30 @@ import (
 )
 
 var _ resource.Resource = &IngestEndpointResource{}
+var _ resource.ResourceWithImportState = &IngestEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &IngestEndpointResource{}
 
 type IngestEndpointResource struct {
-	state appState
+	state *appState
 }
 
 type IngestEndpointResourceModel struct {
-	EnvironmentId  types.String         `tfsdk:"environment_id"`
-	IngestSourceId types.String         `tfsdk:"ingest_source_id"`
-	CreatedAt      timetypes.RFC3339    `tfsdk:"created_at"`
-	Description    types.String         `tfsdk:"description"`
-	Disabled       types.Bool           `tfsdk:"disabled"`
-	Id             types.String         `tfsdk:"id"`
-	Metadata       jsontypes.Normalized `tfsdk:"metadata"`
-	RateLimit      types.Int32          `tfsdk:"rate_limit"`
-	Secret         types.String         `tfsdk:"secret"`
-	Uid            types.String         `tfsdk:"uid"`
-	UpdatedAt      timetypes.RFC3339    `tfsdk:"updated_at"`
-	Url            types.String         `tfsdk:"url"`
+	EnvironmentId   types.String         `tfsdk:"environment_id"`
+	IngestSourceId  types.String         `tfsdk:"ingest_source_id"`
+	CreatedAt       timetypes.RFC3339    `tfsdk:"created_at"`
+	Description     types.String         `tfsdk:"description"`
+	Disabled        types.Bool           `tfsdk:"disabled"`
+	Id              types.String         `tfsdk:"id"`
+	Metadata        jsontypes.Normalized `tfsdk:"metadata"`
+	RateLimit       types.Int32          `tfsdk:"rate_limit"`
+	Secret          types.String         `tfsdk:"secret"`
+	SecretWo        types.String         `tfsdk:"secret_wo"`
+	SecretWoVersion types.Int64          `tfsdk:"secret_wo_version"`
+	PreviousSecret  types.String         `tfsdk:"previous_secret"`
+	RotationTrigger types.Map            `tfsdk:"rotation_trigger"`
+	Uid             types.String         `tfsdk:"uid"`
+	UpdatedAt       timetypes.RFC3339    `tfsdk:"updated_at"`
+	Url             types.String         `tfsdk:"url"`
 }
 
 func NewIngestEndpointResource() resource.Resource {
@@ -51,11 +58,11 @@ func (r *IngestEndpointResource) Configure(ctx context.Context, req resource.Con
 	if req.ProviderData == nil {
 		return
 	}
-	state, ok := req.ProviderData.(appState)
+	state, ok := req.ProviderData.(*appState)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -70,10 +77,11 @@ func (r *IngestEndpointResource) Schema(ctx context.Context, req resource.Schema
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"environment_id": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: ENV_ID_DESC,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"ingest_source_id": schema.StringAttribute{
@@ -109,11 +117,6 @@ func (r *IngestEndpointResource) Schema(ctx context.Context, req resource.Schema
 				int32validator.AtLeast(1),
 				int32validator.AtMost(65535),
 			}},
-			"secret": schema.StringAttribute{
-				Sensitive:           true,
-				Computed:            true,
-				MarkdownDescription: "The endpoint's verification secret.\n" + "Format: base64 encoded random bytes prefixed with whsec_. the server generates the secret.",
-			},
 			"uid": schema.StringAttribute{Optional: true, Validators: []validator.String{
 				stringvalidator.LengthAtLeast(1),
 				stringvalidator.LengthAtMost(256),
@@ -126,6 +129,7 @@ func (r *IngestEndpointResource) Schema(ctx context.Context, req resource.Schema
 			"url": schema.StringAttribute{Required: true},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, endpointSecretAttributes())
 }
 
 func (r *IngestEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -140,7 +144,7 @@ func (r *IngestEndpointResource) Create(ctx context.Context, req resource.Create
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -158,11 +162,17 @@ func (r *IngestEndpointResource) Create(ctx context.Context, req resource.Create
 			rateLimit = ptr(uint16(data.RateLimit.ValueInt32()))
 		}
 
+		secret := endpointCreateSecret(ctx, req, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
 		epIn = models.IngestEndpointIn{
 			Description: strOrNil(data.Description),
 			Disabled:    boolOrNil(data.Disabled),
 			Metadata:    metadata,
 			RateLimit:   rateLimit,
+			Secret:      secret,
 			Uid:         strOrNil(data.Uid),
 			Url:         data.Url.ValueString(),
 		}
@@ -197,7 +207,8 @@ func (r *IngestEndpointResource) Create(ctx context.Context, req resource.Create
 	setCreateState(ctx, resp, rp("id"), types.StringValue(res.Id))
 	setCreateState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
 	setCreateState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
-	setCreateState(ctx, resp, rp("secret"), types.StringValue(secretRes.Key))
+	setCreateState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.Plan, &resp.Diagnostics, secretRes.Key))
+	setCreateState(ctx, resp, rp("previous_secret"), types.StringNull())
 	setCreateState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
 	setCreateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
 	setCreateState(ctx, resp, rp("url"), types.StringValue(res.Url))
@@ -215,7 +226,7 @@ func (r *IngestEndpointResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -223,12 +234,12 @@ func (r *IngestEndpointResource) Read(ctx context.Context, req resource.ReadRequ
 
 	res, err := svx.Ingest.Endpoint.Get(ctx, sourceId, endpId)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to get ingest endpoint")
+		logSvixReadError(ctx, resp, err, "Failed to get ingest endpoint")
 		return
 	}
 	secretRes, err := svx.Ingest.Endpoint.GetSecret(ctx, sourceId, res.Id)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to get ingest endpoint secret")
+		logSvixReadError(ctx, resp, err, "Failed to get ingest endpoint secret")
 		return
 	}
 
@@ -250,7 +261,7 @@ func (r *IngestEndpointResource) Read(ctx context.Context, req resource.ReadRequ
 	setReadState(ctx, resp, rp("id"), types.StringValue(res.Id))
 	setReadState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
 	setReadState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
-	setReadState(ctx, resp, rp("secret"), types.StringValue(secretRes.Key))
+	setReadState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.State, &resp.Diagnostics, secretRes.Key))
 	setReadState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
 	setReadState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
 	setReadState(ctx, resp, rp("url"), types.StringValue(res.Url))
@@ -269,7 +280,7 @@ func (r *IngestEndpointResource) Update(ctx context.Context, req resource.Update
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -302,6 +313,17 @@ func (r *IngestEndpointResource) Update(ctx context.Context, req resource.Update
 		logSvixError(&resp.Diagnostics, err, "Failed to update ingest endpoint")
 		return
 	}
+	rotate, key := endpointSecretRotation(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if rotate {
+		err = svx.Ingest.Endpoint.RotateSecret(ctx, sourceId, endpId, models.IngestEndpointSecretIn{Key: key}, &svix.IngestEndpointRotateSecretOptions{IdempotencyKey: randStr32()})
+		if err != nil {
+			logSvixError(&resp.Diagnostics, err, "Failed to rotate ingest endpoint secret")
+			return
+		}
+	}
 	secretRes, err := svx.Ingest.Endpoint.GetSecret(ctx, sourceId, res.Id)
 	if err != nil {
 		logSvixError(&resp.Diagnostics, err, "Failed to get ingest endpoint secret")
@@ -326,7 +348,7 @@ func (r *IngestEndpointResource) Update(ctx context.Context, req resource.Update
 	setUpdateState(ctx, resp, rp("id"), types.StringValue(res.Id))
 	setUpdateState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
 	setUpdateState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
-	setUpdateState(ctx, resp, rp("secret"), types.StringValue(secretRes.Key))
+	setUpdateState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.Plan, &resp.Diagnostics, secretRes.Key))
 	setUpdateState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
 	setUpdateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
 	setUpdateState(ctx, resp, rp("url"), types.StringValue(res.Url))
@@ -343,15 +365,24 @@ func (r *IngestEndpointResource) Delete(ctx context.Context, req resource.Delete
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
 	}
 
 	err = svx.Ingest.Endpoint.Delete(ctx, sourceId, endpId)
-	if err != nil {
+	if err != nil && !isNotFoundError(err) {
 		logSvixError(&resp.Diagnostics, err, "Failed to delete ingest endpoint")
 		return
 	}
 }
+
+func (r *IngestEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+	planEndpointSecret(ctx, req, resp)
+}
+
+func (r *IngestEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "ingest_source_id", "id")
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &IngestEndpointSecretEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &IngestEndpointSecretEphemeralResource{}
+
+func NewIngestEndpointSecretEphemeralResource() ephemeral.EphemeralResource {
+	return &IngestEndpointSecretEphemeralResource{}
+}
+
+type IngestEndpointSecretEphemeralResource struct {
+	state *appState
+}
+
+type IngestEndpointSecretEphemeralResourceModel struct {
+	EnvironmentId  types.String `tfsdk:"environment_id"`
+	IngestSourceId types.String `tfsdk:"ingest_source_id"`
+	EndpointId     types.String `tfsdk:"endpoint_id"`
+	Secret         types.String `tfsdk:"secret"`
+}
+
+func (r *IngestEndpointSecretEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "svix_ingest_endpoint_secret"
+}
+
+func (r *IngestEndpointSecretEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.state = state
+}
+
+func (r *IngestEndpointSecretEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetch the signing secret of an ingest endpoint without storing it in the state",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The Id of the environment the endpoint belongs to, defaults to the provider's `environment_id`",
+			},
+			"ingest_source_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Id or uid of the ingest source the endpoint belongs to",
+			},
+			"endpoint_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Id or uid of the endpoint",
+			},
+			"secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The ingest endpoint's signing secret, prefixed with `whsec_`",
+			},
+		},
+	}
+}
+
+func (r *IngestEndpointSecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// load config
+	var data IngestEndpointSecretEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.EnvironmentId = types.StringValue(r.state.environmentIdOrDefault(ctx, &resp.Diagnostics, data.EnvironmentId))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create svix client
+	svx, err := r.state.ClientWithEnvId(ctx, data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	res, err := svx.Ingest.Endpoint.GetSecret(ctx, data.IngestSourceId.ValueString(), data.EndpointId.ValueString())
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to get ingest endpoint secret")
+		return
+	}
+
+	// save result
+	data.Secret = types.StringValue(res.Key)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,241 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	svix "github.com/svix/svix-webhooks/go"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+var _ datasource.DataSource = &SvixIngestSourceDataSource{}
+var _ datasource.DataSource = &SvixIngestSourcesDataSource{}
+
+func NewSvixIngestSourceDataSource() datasource.DataSource {
+	return &SvixIngestSourceDataSource{}
+}
+
+func NewSvixIngestSourcesDataSource() datasource.DataSource {
+	return &SvixIngestSourcesDataSource{}
+}
+
+type SvixIngestSourceDataSource struct {
+	state *appState
+}
+
+type SvixIngestSourcesDataSource struct {
+	state *appState
+}
+
+type SvixIngestSourcesDataSourceModel struct {
+	EnvironmentId types.String                    `tfsdk:"environment_id"`
+	IngestSources []SvixIngestSourceResourceModel `tfsdk:"ingest_sources"`
+}
+
+func ingestSourceDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"environment_id": schema.StringAttribute{Computed: true},
+		"id":             schema.StringAttribute{Computed: true},
+		"type":           schema.StringAttribute{Computed: true},
+		"name":           schema.StringAttribute{Computed: true},
+		"uid":            schema.StringAttribute{Computed: true},
+		"config": schema.StringAttribute{
+			Computed:    true,
+			Sensitive:   true,
+			CustomType:  jsontypes.NormalizedType{},
+			Description: "The config may include sensitive fields(webhook signing secret for example)",
+		},
+		"ingest_url": schema.StringAttribute{Computed: true},
+		"created_at": schema.StringAttribute{Computed: true, CustomType: timetypes.RFC3339Type{}},
+		"updated_at": schema.StringAttribute{Computed: true, CustomType: timetypes.RFC3339Type{}},
+	}
+}
+
+func ingestSourceOutToModel(d *diag.Diagnostics, envId string, res models.IngestSourceOut) SvixIngestSourceResourceModel {
+	var configJson *string
+	if res.Config != nil {
+		jsonV, err := json.Marshal(res.Config)
+		if err != nil {
+			d.AddError("Failed to marshal ingest source config", err.Error())
+		} else {
+			configJson = ptr(string(jsonV))
+		}
+	}
+
+	return SvixIngestSourceResourceModel{
+		EnvironmentId: types.StringValue(envId),
+		Id:            types.StringValue(res.Id),
+		Type:          types.StringValue(string(res.Type)),
+		Name:          types.StringValue(res.Name),
+		Uid:           types.StringPointerValue(res.Uid),
+		Config:        jsontypes.NewNormalizedPointerValue(configJson),
+		IngestUrl:     types.StringPointerValue(res.IngestUrl),
+		CreatedAt:     timetypes.NewRFC3339TimeValue(res.CreatedAt),
+		UpdatedAt:     timetypes.NewRFC3339TimeValue(res.UpdatedAt),
+	}
+}
+
+func (d *SvixIngestSourceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "svix_ingest_source"
+}
+
+func (d *SvixIngestSourceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.state = state
+}
+
+func (d *SvixIngestSourceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := ingestSourceDataSourceAttributes()
+	attrs["environment_id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The Id of the environment the ingest source belongs to, defaults to the provider's `environment_id`",
+	}
+	attrs["id"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The Id or uid of the ingest source",
+	}
+	resp.Schema = schema.Schema{
+		Description: "Look up a single ingest source by id or uid",
+		Attributes:  attrs,
+	}
+}
+
+func (d *SvixIngestSourceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// load config
+	var data SvixIngestSourceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	envId := d.state.environmentIdOrDefault(ctx, &resp.Diagnostics, data.EnvironmentId)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.EnvironmentId = types.StringValue(envId)
+
+	// create svix client
+	svx, err := d.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	res, err := svx.Ingest.Source.Get(ctx, data.Id.ValueString())
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to get ingest source")
+		return
+	}
+
+	// save state
+	out := ingestSourceOutToModel(&resp.Diagnostics, envId, *res)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// keep the configured value, so looking up a source by uid doesn't produce an inconsistent result
+	out.Id = data.Id
+	resp.Diagnostics.Append(resp.State.Set(ctx, &out)...)
+}
+
+func (d *SvixIngestSourcesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "svix_ingest_sources"
+}
+
+func (d *SvixIngestSourcesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	state, ok := req.ProviderData.(*appState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.state = state
+}
+
+func (d *SvixIngestSourcesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the ingest sources of an environment",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The Id of the environment to list the ingest sources of, defaults to the provider's `environment_id`",
+			},
+			"ingest_sources": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: ingestSourceDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *SvixIngestSourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// load config
+	var data SvixIngestSourcesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	envId := d.state.environmentIdOrDefault(ctx, &resp.Diagnostics, data.EnvironmentId)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.EnvironmentId = types.StringValue(envId)
+
+	// create svix client
+	svx, err := d.state.ClientWithEnvId(ctx, envId)
+	if err != nil {
+		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
+		return
+	}
+
+	// call api
+	var sources []models.IngestSourceOut
+	opts := svix.IngestSourceListOptions{Limit: ptr(uint64(250))}
+	for {
+		res, err := svx.Ingest.Source.List(ctx, &opts)
+		if err != nil {
+			logSvixError(&resp.Diagnostics, err, "Failed to list ingest sources")
+			return
+		}
+		sources = append(sources, res.Data...)
+		if res.Done || res.Iterator == nil {
+			break
+		}
+		opts.Iterator = res.Iterator
+	}
+
+	// save state
+	data.IngestSources = []SvixIngestSourceResourceModel{}
+	for _, src := range sources {
+		data.IngestSources = append(data.IngestSources, ingestSourceOutToModel(&resp.Diagnostics, envId, src))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -23,9 +23,11 @@ import (
 )
 
 var _ resource.Resource = &SvixIngestSourceResource{}
+var _ resource.ResourceWithImportState = &SvixIngestSourceResource{}
+var _ resource.ResourceWithModifyPlan = &SvixIngestSourceResource{}
 
 type SvixIngestSourceResource struct {
-	state appState
+	state *appState
 }
 
 type SvixIngestSourceResourceModel struct {
@@ -48,11 +50,11 @@ func (r *SvixIngestSourceResource) Configure(ctx context.Context, req resource.C
 	if req.ProviderData == nil {
 		return
 	}
-	state, ok := req.ProviderData.(appState)
+	state, ok := req.ProviderData.(*appState)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -107,10 +109,11 @@ func (r *SvixIngestSourceResource) Schema(ctx context.Context, req resource.Sche
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"environment_id": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: ENV_ID_DESC,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"type": schema.StringAttribute{
@@ -193,7 +196,7 @@ func (r *SvixIngestSourceResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -258,7 +261,7 @@ func (r *SvixIngestSourceResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -266,7 +269,7 @@ func (r *SvixIngestSourceResource) Read(ctx context.Context, req resource.ReadRe
 
 	res, err := svx.Ingest.Source.Get(ctx, srcId)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to get ingest source")
+		logSvixReadError(ctx, resp, err, "Failed to get ingest source")
 		return
 	}
 
@@ -304,7 +307,7 @@ func (r *SvixIngestSourceResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -362,14 +365,14 @@ func (r *SvixIngestSourceResource) Delete(ctx context.Context, req resource.Dele
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
 	}
 
 	err = svx.Ingest.Source.Delete(ctx, srcId)
-	if err != nil {
+	if err != nil && !isNotFoundError(err) {
 		logSvixError(&resp.Diagnostics, err, "Failed to delete ingest source")
 		return
 	}
@@ -475,3 +478,11 @@ func createIngestConfigFromCurrentValAndPlan(configPlan string, configCurrent mo
 
 	return ptr(string(ret)), nil
 }
+
+func (r *SvixIngestSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+}
+
+func (r *SvixIngestSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "id")
+}
@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tflog subsystem of the http logs, enabled with `TF_LOG_PROVIDER=DEBUG` (or `TRACE` to include the bodies)
+const httpLogSubsystem = "http"
+
+// secrets that are masked anywhere in the http logs
+var httpLogSecretRegexes = []*regexp.Regexp{
+	// api tokens, in the Authorization header and in api token responses
+	regexp.MustCompile(`(?i)bearer\s+\S+`),
+	regexp.MustCompile(`\b(test)?sk_[A-Za-z0-9_\-.|]+`),
+	// endpoint signing secrets
+	regexp.MustCompile(`whsec_[A-Za-z0-9+/=]+`),
+	// the app portal magic links contain a token
+	regexp.MustCompile(`#key=[^"\s]+`),
+	// the private key of an endpoint mTLS identity
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+// logs every request sent to the svix api, each attempt of a retried request is logged separately.
+//
+// Request and response bodies are only logged at TRACE, with the values of sensitive fields redacted
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), httpLogSubsystem)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, httpLogSubsystem, httpLogSecretRegexes...)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, httpLogSubsystem, httpLogSecretRegexes...)
+
+	fields := map[string]any{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	for field, header := range map[string]string{
+		"request_id":      "svix-req-id",
+		"idempotency_key": "idempotency-key",
+		"retry_count":     "svix-retry-count",
+	} {
+		if v := req.Header.Get(header); v != "" {
+			fields[field] = v
+		}
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			if len(b) > 0 {
+				tflog.SubsystemTrace(ctx, httpLogSubsystem, "svix api request body", fields, map[string]any{"body": redactJsonBody(b)})
+			}
+		}
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "svix api request failed", fields, map[string]any{"error": err.Error()})
+		return nil, err
+	}
+	fields["status"] = res.StatusCode
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "svix api request", fields)
+
+	// read the whole body so it can be logged, the sdk reads it all anyway
+	b, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(b))
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "svix api response body", fields, map[string]any{"body": redactJsonBody(b)})
+	return res, nil
+}
+
+// json fields whose values are never logged: secrets, custom headers, ingest source configs and mTLS identities
+func isSensitiveJsonKey(key string) bool {
+	key = strings.ToLower(key)
+	switch key {
+	case "config", "headers", "identity", "key":
+		return true
+	}
+	for _, s := range []string{"secret", "password", "token", "privatekey"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact the sensitive fields of a json body, bodies that aren't json are returned as is (secrets are still masked by the regexes)
+func redactJsonBody(b []byte) string {
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return string(b)
+	}
+	out, err := json.Marshal(redactJsonValue(v))
+	if err != nil {
+		return string(b)
+	}
+	return string(out)
+}
+
+func redactJsonValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isSensitiveJsonKey(key) && value != nil {
+				v[key] = "***"
+			} else {
+				v[key] = redactJsonValue(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactJsonValue(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+
+	svix "github.com/svix/svix-webhooks/go"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+// The svix SDK doesn't expose every API endpoint the provider needs,
+// this file contains minimal wrappers around the ones that are missing.
+
+type listResponseApiTokenCensoredOut struct {
+	Data     []models.ApiTokenCensoredOut `json:"data"`
+	Done     bool                         `json:"done"`
+	Iterator *string                      `json:"iterator,omitempty"`
+}
+
+// error returned by `managementRequest` when the server responds with a non 2xx status code
+type managementApiError struct {
+	status int
+	body   []byte
+}
+
+func (e *managementApiError) Error() string {
+	return fmt.Sprintf("status code: %d %s\n\nbody: %s", e.status, http.StatusText(e.status), string(e.body))
+}
+
+// send a request to the API using the token of the environment, `body` is sent as json if it isn't nil.
+// The response is unmarshalled into `out`, unless it is nil
+func (s *appState) managementRequest(ctx context.Context, envId string, method string, path string, query url.Values, body any, out any) error {
+	reqUrl := s.serverUrl.JoinPath(path)
+	reqUrl.RawQuery = query.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqUrl.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	bearerToken, err := s.bearerToken(ctx, envId)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+bearerToken)
+	req.Header.Set("User-Agent", fmt.Sprintf("svix-libs/%s/go/%s go/%s", svix.Version, userAgentSuffix, runtime.Version()))
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &managementApiError{status: res.StatusCode, body: resBody}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(resBody, out)
+}
+
+// list all the api tokens of an environment
+func (s *appState) listApiTokens(ctx context.Context, envId string) ([]models.ApiTokenCensoredOut, error) {
+	var tokens []models.ApiTokenCensoredOut
+	query := url.Values{"limit": {"250"}}
+	for {
+		var page listResponseApiTokenCensoredOut
+		err := s.managementRequest(ctx, envId, http.MethodGet, fmt.Sprintf("/api/v1/management/authentication/%s/api-token", url.PathEscape(envId)), query, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, page.Data...)
+		if page.Done || page.Iterator == nil {
+			return tokens, nil
+		}
+		query.Set("iterator", *page.Iterator)
+	}
+}
+
+type endpointMtlsConfigIn struct {
+	// PEM encoded private key and certificate
+	Identity string `json:"identity"`
+}
+
+// the api doesn't return the identity, the config is only found (not 404) while one is set
+type endpointMtlsConfigOut struct{}
+
+type clientSecretJwtParamsIn struct {
+	SecretBase64     string  `json:"secretBase64"`
+	SecretId         *string `json:"secretId,omitempty"`
+	SigningAlgorithm string  `json:"signingAlgorithm"`
+	TokenExpirySecs  *int64  `json:"tokenExpirySecs,omitempty"`
+}
+
+type endpointOauthConfigIn struct {
+	AuthMethod   string                   `json:"authMethod"`
+	ClientId     string                   `json:"clientId"`
+	ClientSecret *string                  `json:"clientSecret,omitempty"`
+	ExtraHeaders map[string]string        `json:"extraHeaders,omitempty"`
+	ExtraParams  map[string]string        `json:"extraParams,omitempty"`
+	GrantType    string                   `json:"grantType"`
+	JwtParams    *clientSecretJwtParamsIn `json:"jwtParams,omitempty"`
+	RefreshToken *string                  `json:"refreshToken,omitempty"`
+	Scopes       []string                 `json:"scopes,omitempty"`
+	TokenUrl     string                   `json:"tokenUrl"`
+}
+
+type endpointOauthConfigOut struct {
+	AuthMethod   string            `json:"authMethod"`
+	ClientId     string            `json:"clientId"`
+	ExtraHeaders map[string]string `json:"extraHeaders,omitempty"`
+	ExtraParams  map[string]string `json:"extraParams,omitempty"`
+	GrantType    string            `json:"grantType"`
+	Scopes       []string          `json:"scopes,omitempty"`
+	TokenUrl     string            `json:"tokenUrl"`
+}
+
+func endpointApiPath(appId, endpointId, suffix string) string {
+	return fmt.Sprintf("/api/v1/app/%s/endpoint/%s/%s", url.PathEscape(appId), url.PathEscape(endpointId), suffix)
+}
+
+func (s *appState) getEndpointMtls(ctx context.Context, envId, appId, endpointId string) (*endpointMtlsConfigOut, error) {
+	var out endpointMtlsConfigOut
+	err := s.managementRequest(ctx, envId, http.MethodGet, endpointApiPath(appId, endpointId, "mtls"), nil, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *appState) updateEndpointMtls(ctx context.Context, envId, appId, endpointId string, in endpointMtlsConfigIn) error {
+	return s.managementRequest(ctx, envId, http.MethodPut, endpointApiPath(appId, endpointId, "mtls"), nil, in, nil)
+}
+
+func (s *appState) deleteEndpointMtls(ctx context.Context, envId, appId, endpointId string) error {
+	return s.managementRequest(ctx, envId, http.MethodDelete, endpointApiPath(appId, endpointId, "mtls"), nil, nil, nil)
+}
+
+func (s *appState) getEndpointOauth(ctx context.Context, envId, appId, endpointId string) (*endpointOauthConfigOut, error) {
+	var out endpointOauthConfigOut
+	err := s.managementRequest(ctx, envId, http.MethodGet, endpointApiPath(appId, endpointId, "oauth"), nil, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *appState) updateEndpointOauth(ctx context.Context, envId, appId, endpointId string, in endpointOauthConfigIn) error {
+	return s.managementRequest(ctx, envId, http.MethodPut, endpointApiPath(appId, endpointId, "oauth"), nil, in, nil)
+}
+
+func (s *appState) deleteEndpointOauth(ctx context.Context, envId, appId, endpointId string) error {
+	return s.managementRequest(ctx, envId, http.MethodDelete, endpointApiPath(appId, endpointId, "oauth"), nil, nil, nil)
+}
@@ -2,9 +2,7 @@ package model
 
 import (
 	"context"
-	"log"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -15,9 +13,6 @@ import (
 func ptr[T any](value T) *T {
 	return &value
 }
-func Spw(v any) {
-	log.Println(spew.Sdump(v))
-}
 
 // Terraform wrapper around `svixmodels.FontSizeConfig`
 type FontSizeConfig_TF struct {
@@ -49,8 +44,24 @@ type EnvironmentSettingsResourceModel struct {
 	WhitelabelHeaders          types.Bool   `tfsdk:"whitelabel_headers"`
 	WipeSuccessfulPayload      types.Bool   `tfsdk:"delete_payload_on_successful_delivery"`
 
+	ReadOnly                      types.Bool   `tfsdk:"read_only"`
+	EnableApplicationAlerts       types.Bool   `tfsdk:"enable_application_alerts"`
+	ApplicationAlertEvents        types.List   `tfsdk:"application_alert_events"`
+	ApplicationAlertsDashboardUrl types.String `tfsdk:"application_alerts_dashboard_url"`
+	ApplicationAlertsLogoUrl      types.String `tfsdk:"application_alerts_logo_url"`
+	EnableIntegrationManagement   types.Bool   `tfsdk:"enable_integration_management"`
+	EnableMsgAtmptLog             types.Bool   `tfsdk:"enable_message_attempt_log"`
+	SendSvixWebhookHeaders        types.Bool   `tfsdk:"send_svix_webhook_headers"`
+	ShowFeatureTooltips           types.Bool   `tfsdk:"show_feature_tooltips"`
+	ShowUseSvixPlay               types.Bool   `tfsdk:"show_use_svix_play"`
+	WebhooksAutoConfig            types.Bool   `tfsdk:"webhooks_auto_config"`
+	RetryPolicy                   types.List   `tfsdk:"retry_schedule"`
+
 	WhitelabelSettings basetypes.ObjectValue `tfsdk:"whitelabel_settings"`
 	OtelConfig         basetypes.ObjectValue `tfsdk:"otel_config"`
+
+	ResetUnmanagedSettings types.Bool   `tfsdk:"reset_unmanaged_settings"`
+	OnDestroy              types.String `tfsdk:"on_destroy"`
 }
 
 type WhitelabelSettings struct {
@@ -104,6 +115,20 @@ func PatchSettingsInternalPatchWithPlan(
 ) svixmodels.SettingsInternalPatch {
 	// initialize model as empty
 	outModel := svixmodels.SettingsInternalPatch{}
+	// with `reset_unmanaged_settings`, the whitelabel settings missing from the plan are reset to their default (null)
+	reset := planedModel.ResetUnmanagedSettings.ValueBool()
+
+	if reset && planedModel.WhitelabelSettings.IsNull() {
+		outModel.DisplayName.Set(nil)
+		outModel.CustomBaseFontSize.Set(nil)
+		outModel.CustomFontFamily.Set(nil)
+		outModel.CustomFontFamilyUrl.Set(nil)
+		outModel.CustomLogoUrl.Set(nil)
+		outModel.CustomThemeOverride.Set(nil)
+		outModel.ColorPaletteDark.Set(nil)
+		outModel.ColorPaletteLight.Set(nil)
+		outModel.CustomStringsOverride.Set(nil)
+	}
 
 	if !planedModel.WhitelabelSettings.IsUnknown() && !planedModel.WhitelabelSettings.IsNull() {
 		var planedWhitelabelSettings WhitelabelSettings
@@ -147,7 +172,11 @@ func PatchSettingsInternalPatchWithPlan(
 			}
 		}
 
-		{
+		if planedWhitelabelSettings.BorderRadius.IsNull() {
+			if reset {
+				outModel.CustomThemeOverride.Set(nil)
+			}
+		} else {
 			var planedBorderRadius BorderRadius
 			d.Append(planedWhitelabelSettings.BorderRadius.As(ctx, &planedBorderRadius, basetypes.ObjectAsOptions{
 				UnhandledNullAsEmpty:    false,
@@ -175,14 +204,22 @@ func PatchSettingsInternalPatchWithPlan(
 			}
 		}
 
-		{
+		if planedWhitelabelSettings.ColorPaletteDark.IsNull() {
+			if reset {
+				outModel.ColorPaletteDark.Set(nil)
+			}
+		} else {
 			colorPaletteOut := patchColorPaletteWithPlan(ctx, d, planedWhitelabelSettings.ColorPaletteDark)
 			if colorPaletteOut != nil {
 				outModel.ColorPaletteDark.Set(colorPaletteOut)
 			}
 		}
 
-		{
+		if planedWhitelabelSettings.ColorPaletteLight.IsNull() {
+			if reset {
+				outModel.ColorPaletteLight.Set(nil)
+			}
+		} else {
 			colorPaletteOut := patchColorPaletteWithPlan(ctx, d, planedWhitelabelSettings.ColorPaletteLight)
 			if colorPaletteOut != nil {
 				outModel.ColorPaletteLight.Set(colorPaletteOut)
@@ -190,6 +227,9 @@ func PatchSettingsInternalPatchWithPlan(
 		}
 
 		{
+			if reset && planedWhitelabelSettings.CustomStringsOverride.IsNull() {
+				outModel.CustomStringsOverride.Set(nil)
+			}
 			var planedCustomStringsOverride CustomStringsOverride_TF
 			if !planedWhitelabelSettings.CustomStringsOverride.IsNull() && !planedWhitelabelSettings.CustomStringsOverride.IsUnknown() {
 				d.Append(planedWhitelabelSettings.CustomStringsOverride.As(ctx, &planedCustomStringsOverride, basetypes.ObjectAsOptions{
@@ -249,6 +289,51 @@ func PatchSettingsInternalPatchWithPlan(
 	if !planedModel.WipeSuccessfulPayload.IsUnknown() {
 		outModel.WipeSuccessfulPayload = planedModel.WipeSuccessfulPayload.ValueBoolPointer()
 	}
+	if !planedModel.ReadOnly.IsUnknown() {
+		outModel.ReadOnly = planedModel.ReadOnly.ValueBoolPointer()
+	}
+	if !planedModel.EnableApplicationAlerts.IsUnknown() {
+		outModel.EnableApplicationAlerts = planedModel.EnableApplicationAlerts.ValueBoolPointer()
+	}
+	if !planedModel.ApplicationAlertEvents.IsUnknown() && !planedModel.ApplicationAlertEvents.IsNull() {
+		// an empty list clears the events, so it must not be sent as null
+		events := []svixmodels.ApplicationAlertEvent{}
+		d.Append(planedModel.ApplicationAlertEvents.ElementsAs(ctx, &events, false)...)
+		outModel.ApplicationAlertEvents = events
+	}
+	if !planedModel.ApplicationAlertsDashboardUrl.IsUnknown() {
+		outModel.ApplicationAlertsDashboardUrl.Set(planedModel.ApplicationAlertsDashboardUrl.ValueStringPointer())
+	}
+	if !planedModel.ApplicationAlertsLogoUrl.IsUnknown() {
+		outModel.ApplicationAlertsLogoUrl.Set(planedModel.ApplicationAlertsLogoUrl.ValueStringPointer())
+	}
+	if !planedModel.EnableIntegrationManagement.IsUnknown() {
+		outModel.EnableIntegrationManagement = planedModel.EnableIntegrationManagement.ValueBoolPointer()
+	}
+	if !planedModel.EnableMsgAtmptLog.IsUnknown() {
+		outModel.EnableMsgAtmptLog = planedModel.EnableMsgAtmptLog.ValueBoolPointer()
+	}
+	if !planedModel.SendSvixWebhookHeaders.IsUnknown() {
+		outModel.SendSvixWebhookHeaders = planedModel.SendSvixWebhookHeaders.ValueBoolPointer()
+	}
+	if !planedModel.ShowFeatureTooltips.IsUnknown() {
+		outModel.ShowFeatureTooltips = planedModel.ShowFeatureTooltips.ValueBoolPointer()
+	}
+	if !planedModel.ShowUseSvixPlay.IsUnknown() {
+		outModel.ShowUseSvixPlay = planedModel.ShowUseSvixPlay.ValueBoolPointer()
+	}
+	if !planedModel.WebhooksAutoConfig.IsUnknown() {
+		outModel.WebhooksAutoConfig = planedModel.WebhooksAutoConfig.ValueBoolPointer()
+	}
+	if !planedModel.RetryPolicy.IsUnknown() {
+		if planedModel.RetryPolicy.IsNull() {
+			outModel.RetryPolicy.Set(nil)
+		} else {
+			var retryPolicy []int32
+			d.Append(planedModel.RetryPolicy.ElementsAs(ctx, &retryPolicy, false)...)
+			outModel.RetryPolicy.Set(&retryPolicy)
+		}
+	}
 	return outModel
 }
 
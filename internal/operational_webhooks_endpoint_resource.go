@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
@@ -25,6 +26,8 @@ import (
 )
 
 var _ resource.Resource = &OperationalWebhooksEndpointResource{}
+var _ resource.ResourceWithImportState = &OperationalWebhooksEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &OperationalWebhooksEndpointResource{}
 
 var opWebhookTypes = []string{
 	"background_task.finished",
@@ -44,22 +47,26 @@ func NewOperationalWebhooksEndpoint() resource.Resource {
 }
 
 type OperationalWebhooksEndpointResource struct {
-	state appState
+	state *appState
 }
 
 type OperationalWebhooksEndpointResourceModel struct {
-	EnvironmentId types.String         `tfsdk:"environment_id"`
-	CreatedAt     timetypes.RFC3339    `tfsdk:"created_at"`
-	Description   types.String         `tfsdk:"description"`
-	Disabled      types.Bool           `tfsdk:"disabled"`
-	FilterTypes   types.List           `tfsdk:"filter_types"`
-	Id            types.String         `tfsdk:"id"`
-	Metadata      jsontypes.Normalized `tfsdk:"metadata"`
-	RateLimit     types.Int32          `tfsdk:"rate_limit"`
-	Secret        types.String         `tfsdk:"secret"`
-	Uid           types.String         `tfsdk:"uid"`
-	UpdatedAt     timetypes.RFC3339    `tfsdk:"updated_at"`
-	Url           types.String         `tfsdk:"url"`
+	EnvironmentId   types.String         `tfsdk:"environment_id"`
+	CreatedAt       timetypes.RFC3339    `tfsdk:"created_at"`
+	Description     types.String         `tfsdk:"description"`
+	Disabled        types.Bool           `tfsdk:"disabled"`
+	FilterTypes     types.List           `tfsdk:"filter_types"`
+	Id              types.String         `tfsdk:"id"`
+	Metadata        jsontypes.Normalized `tfsdk:"metadata"`
+	RateLimit       types.Int32          `tfsdk:"rate_limit"`
+	Secret          types.String         `tfsdk:"secret"`
+	SecretWo        types.String         `tfsdk:"secret_wo"`
+	SecretWoVersion types.Int64          `tfsdk:"secret_wo_version"`
+	PreviousSecret  types.String         `tfsdk:"previous_secret"`
+	RotationTrigger types.Map            `tfsdk:"rotation_trigger"`
+	Uid             types.String         `tfsdk:"uid"`
+	UpdatedAt       timetypes.RFC3339    `tfsdk:"updated_at"`
+	Url             types.String         `tfsdk:"url"`
 }
 
 func (r *OperationalWebhooksEndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -70,10 +77,11 @@ func (r *OperationalWebhooksEndpointResource) Schema(ctx context.Context, req re
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"environment_id": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: ENV_ID_DESC,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"created_at": schema.StringAttribute{
@@ -107,11 +115,6 @@ func (r *OperationalWebhooksEndpointResource) Schema(ctx context.Context, req re
 				int32validator.AtLeast(1),
 				int32validator.AtMost(65535),
 			}},
-			"secret": schema.StringAttribute{
-				Sensitive:           true,
-				Computed:            true,
-				MarkdownDescription: "The endpoint's verification secret.\n" + "Format: base64 encoded random bytes prefixed with whsec_. the server generates the secret.",
-			},
 			"uid": schema.StringAttribute{Optional: true, Validators: []validator.String{
 				stringvalidator.LengthAtLeast(1),
 				stringvalidator.LengthAtMost(256),
@@ -124,17 +127,18 @@ func (r *OperationalWebhooksEndpointResource) Schema(ctx context.Context, req re
 			"url": schema.StringAttribute{Required: true},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, endpointSecretAttributes())
 }
 
 func (r *OperationalWebhooksEndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	state, ok := req.ProviderData.(appState)
+	state, ok := req.ProviderData.(*appState)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *appState, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -152,7 +156,7 @@ func (r *OperationalWebhooksEndpointResource) Create(ctx context.Context, req re
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -177,12 +181,18 @@ func (r *OperationalWebhooksEndpointResource) Create(ctx context.Context, req re
 			rateLimit = ptr(uint16(data.RateLimit.ValueInt32()))
 		}
 
+		secret := endpointCreateSecret(ctx, req, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
 		opWebhookIn = models.OperationalWebhookEndpointIn{
 			Description: strOrNil(data.Description),
 			Disabled:    boolOrNil(data.Disabled),
 			FilterTypes: filterTypes,
 			Metadata:    metadata,
 			RateLimit:   rateLimit,
+			Secret:      secret,
 			Uid:         strOrNil(data.Uid),
 			Url:         data.Url.ValueString(),
 		}
@@ -222,7 +232,8 @@ func (r *OperationalWebhooksEndpointResource) Create(ctx context.Context, req re
 	setCreateState(ctx, resp, rp("id"), types.StringValue(res.Id))
 	setCreateState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
 	setCreateState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
-	setCreateState(ctx, resp, rp("secret"), types.StringValue(secretRes.Key))
+	setCreateState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.Plan, &resp.Diagnostics, secretRes.Key))
+	setCreateState(ctx, resp, rp("previous_secret"), types.StringNull())
 	setCreateState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
 	setCreateState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
 	setCreateState(ctx, resp, rp("url"), types.StringValue(res.Url))
@@ -240,7 +251,7 @@ func (r *OperationalWebhooksEndpointResource) Read(ctx context.Context, req reso
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -249,12 +260,12 @@ func (r *OperationalWebhooksEndpointResource) Read(ctx context.Context, req reso
 	// call api
 	res, err := svx.OperationalWebhookEndpoint.Get(ctx, data.Id.ValueString())
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to read operational webhooks endpoint")
+		logSvixReadError(ctx, resp, err, "Failed to read operational webhooks endpoint")
 		return
 	}
 	secretRes, err := svx.OperationalWebhook.Endpoint.GetSecret(ctx, res.Id)
 	if err != nil {
-		logSvixError(&resp.Diagnostics, err, "Failed to get op webhook endpoint secret")
+		logSvixReadError(ctx, resp, err, "Failed to get op webhook endpoint secret")
 		return
 	}
 	// save state
@@ -277,7 +288,7 @@ func (r *OperationalWebhooksEndpointResource) Read(ctx context.Context, req reso
 	setReadState(ctx, resp, rp("id"), types.StringValue(res.Id))
 	setReadState(ctx, resp, rp("metadata"), jsontypes.NewNormalizedValue(string(metadataOut)))
 	setReadState(ctx, resp, rp("rate_limit"), types.Int32PointerValue(rateLimitOut))
-	setReadState(ctx, resp, rp("secret"), types.StringValue(secretRes.Key))
+	setReadState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.State, &resp.Diagnostics, secretRes.Key))
 	setReadState(ctx, resp, rp("uid"), types.StringPointerValue(res.Uid))
 	setReadState(ctx, resp, rp("updated_at"), timetypes.NewRFC3339TimeValue(res.UpdatedAt))
 	setReadState(ctx, resp, rp("url"), types.StringValue(res.Url))
@@ -295,7 +306,7 @@ func (r *OperationalWebhooksEndpointResource) Update(ctx context.Context, req re
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
@@ -332,6 +343,22 @@ func (r *OperationalWebhooksEndpointResource) Update(ctx context.Context, req re
 		logSvixError(&resp.Diagnostics, err, "Failed to update operational webhooks endpoint")
 		return
 	}
+	rotate, key := endpointSecretRotation(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if rotate {
+		err = svx.OperationalWebhook.Endpoint.RotateSecret(ctx, epId, models.OperationalWebhookEndpointSecretIn{Key: key}, &svix.OperationalWebhookEndpointRotateSecretOptions{IdempotencyKey: randStr32()})
+		if err != nil {
+			logSvixError(&resp.Diagnostics, err, "Failed to rotate op webhook endpoint secret")
+			return
+		}
+	}
+	secretRes, err := svx.OperationalWebhook.Endpoint.GetSecret(ctx, epId)
+	if err != nil {
+		logSvixError(&resp.Diagnostics, err, "Failed to get op webhook endpoint secret")
+		return
+	}
 
 	// save state
 	outMetadata := mapStringTToString(&resp.Diagnostics, &res.Metadata)
@@ -343,6 +370,7 @@ func (r *OperationalWebhooksEndpointResource) Update(ctx context.Context, req re
 	setUpdateState(ctx, resp, rp("filter_types"), res.FilterTypes)
 	setUpdateState(ctx, resp, rp("metadata"), outMetadata)
 	setUpdateState(ctx, resp, rp("rate_limit"), res.RateLimit)
+	setUpdateState(ctx, resp, rp("secret"), endpointSecretState(ctx, req.Plan, &resp.Diagnostics, secretRes.Key))
 	setUpdateState(ctx, resp, rp("uid"), res.Uid)
 	setUpdateState(ctx, resp, rp("url"), res.Url)
 
@@ -357,15 +385,24 @@ func (r *OperationalWebhooksEndpointResource) Delete(ctx context.Context, req re
 	}
 
 	// create svix client
-	svx, err := r.state.ClientWithEnvId(envId)
+	svx, err := r.state.ClientWithEnvId(ctx, envId)
 	if err != nil {
 		resp.Diagnostics.AddError(UNABLE_TO_CREATE_SVIX_CLIENT, err.Error())
 		return
 	}
 
 	err = svx.OperationalWebhookEndpoint.Delete(ctx, epId)
-	if err != nil {
+	if err != nil && !isNotFoundError(err) {
 		logSvixError(&resp.Diagnostics, err, "Failed to delete operational webhooks endpoint")
 	}
 
 }
+
+func (r *OperationalWebhooksEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.state.planEnvironmentId(ctx, req, resp)
+	planEndpointSecret(ctx, req, resp)
+}
+
+func (r *OperationalWebhooksEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromId(ctx, req, resp, "environment_id", "id")
+}
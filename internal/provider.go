@@ -3,15 +3,26 @@ package internal
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	svix "github.com/svix/svix-webhooks/go"
 	svix_internal "github.com/svix/svix-webhooks/go/internalapi"
@@ -25,8 +36,17 @@ type SvixProvider struct {
 }
 
 type SvixProviderModel struct {
-	ServerUrl types.String `tfsdk:"server_url"`
-	Token     types.String `tfsdk:"token"`
+	ServerUrl         types.String         `tfsdk:"server_url"`
+	Region            types.String         `tfsdk:"region"`
+	Token             types.String         `tfsdk:"token"`
+	TokenFile         types.String         `tfsdk:"token_file"`
+	TokenCommand      types.List           `tfsdk:"token_command"`
+	EnvironmentTokens types.Map            `tfsdk:"environment_tokens"`
+	EnvironmentId     types.String         `tfsdk:"environment_id"`
+	MaxRetries        types.Int64          `tfsdk:"max_retries"`
+	RetryMinBackoff   timetypes.GoDuration `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff   timetypes.GoDuration `tfsdk:"retry_max_backoff"`
+	RequestTimeout    timetypes.GoDuration `tfsdk:"request_timeout"`
 }
 
 func (p *SvixProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -38,49 +58,162 @@ func (p *SvixProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"server_url": schema.StringAttribute{
-				MarkdownDescription: "Svix server url",
-				Optional:            true,
+				MarkdownDescription: "Svix server url, can also be set with the `SVIX_SERVER_URL` environment variable. " +
+					"Defaults to the api url of `region`, or of the region the token was issued in",
+				Optional: true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Svix region (one of `%s`), can also be set with the `SVIX_REGION` environment variable. "+
+					"Defaults to the region the token was issued in", strings.Join(regionNames(), "`, `")),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(regionNames()...),
+				},
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "Api token",
+				MarkdownDescription: "Api token, can also be set with the `SVIX_TOKEN` environment variable",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_file"), path.MatchRoot("token_command")),
+				},
+			},
+			"token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the api token, read every time the provider is configured. Can also be set with the `SVIX_TOKEN_FILE` environment variable",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_command")),
+				},
+			},
+			"token_command": schema.ListAttribute{
+				MarkdownDescription: "Command (and arguments) printing the api token, in the same format as kubectl credential plugins: " +
+					"`{\"status\": {\"token\": \"...\", \"expirationTimestamp\": \"2006-01-02T15:04:05Z\"}}`. " +
+					"`expirationTimestamp` is optional, if set the command is run again when the token is about to expire",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"environment_tokens": schema.MapAttribute{
+				MarkdownDescription: "Environment scoped api tokens, keyed by environment id. Resources in these environments use the token as is, " +
+					"instead of the organization token with the environment id suffixed",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Default environment id, used by resources that don't set `environment_id`",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("How many times a request is retried after a network error, a 429 or a 5xx response. Defaults to `%d`", defaultRetryConfig.maxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 20),
+				},
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Minimum time to wait before retrying a request, doubled after every retry. A `Retry-After` header sent by the server takes precedence. Defaults to `%s`", defaultRetryConfig.minBackoff),
+				Optional:            true,
+				CustomType:          timetypes.GoDurationType{},
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Maximum time to wait before retrying a request. Defaults to `%s`", defaultRetryConfig.maxBackoff),
+				Optional:            true,
+				CustomType:          timetypes.GoDurationType{},
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Timeout of a single request attempt, `0s` disables the timeout. Defaults to `%s`", defaultRetryConfig.requestTimeout),
+				Optional:            true,
+				CustomType:          timetypes.GoDurationType{},
 			},
 		},
 	}
 }
 
 func (p *SvixProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
-	token := os.Getenv("SVIX_TOKEN")
 	server_url := os.Getenv("SVIX_SERVER_URL")
+	region := os.Getenv("SVIX_REGION")
+	environment_id := os.Getenv("SVIX_ENVIRONMENT_ID")
 	var data SvixProviderModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-
-	if data.Token.ValueString() != "" {
-		token = data.Token.ValueString()
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
 	if data.ServerUrl.ValueString() != "" {
 		server_url = data.ServerUrl.ValueString()
 	}
+	if data.Region.ValueString() != "" {
+		region = data.Region.ValueString()
+	}
+	if _, ok := regionServerUrls[region]; region != "" && !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Invalid Region",
+			fmt.Sprintf("Unknown region %q, expected one of: %s", region, strings.Join(regionNames(), ", ")),
+		)
+	}
+	if data.EnvironmentId.ValueString() != "" {
+		environment_id = data.EnvironmentId.ValueString()
+	}
 
-	if token == "" {
+	var environmentTokens map[string]string
+	resp.Diagnostics.Append(data.EnvironmentTokens.ElementsAs(ctx, &environmentTokens, false)...)
+	for envId, token := range environmentTokens {
+		if token == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("environment_tokens").AtMapKey(envId),
+				"Missing Environment Token",
+				fmt.Sprintf("The token of environment %s is empty.", envId),
+			)
+		}
+	}
+
+	tokens, err := configureTokenSource(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get the API token", err.Error())
+	} else if tokens == nil && len(environmentTokens) == 0 {
 		resp.Diagnostics.AddError(
 			"Missing API Token Configuration",
 			"While configuring the provider, the API token was not found in "+
-				"the SVIX_TOKEN environment variable or provider "+
-				"configuration block token attribute.",
+				"the SVIX_TOKEN or SVIX_TOKEN_FILE environment variables or provider "+
+				"configuration block token, token_file, token_command or environment_tokens attributes.",
 		)
 	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenRegion, err := configuredTokensRegion(ctx, tokens, environmentTokens)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid API Token Configuration", err.Error())
+		return
+	}
+	if region != "" && tokenRegion != "" && region != tokenRegion {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Region Mismatch",
+			fmt.Sprintf("The provider is configured for the %q region, but the API token was issued in the %q region.", region, tokenRegion),
+		)
+		return
+	}
+	if region == "" {
+		region = tokenRegion
+	}
+	if server_url == "" {
+		server_url = regionServerUrls[region]
+	}
 	if server_url == "" {
 		resp.Diagnostics.AddError(
 			"Missing Server URL Configuration",
 			"While configuring the provider, the Server URL was not found in "+
 				"the SVIX_SERVER_URL environment variable or provider "+
-				"configuration block server_url attribute.",
+				"configuration block server_url attribute, and the region couldn't be inferred from "+
+				"the SVIX_REGION environment variable, the region attribute or the API token.",
 		)
-	}
-	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -90,23 +223,117 @@ func (p *SvixProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		resp.Diagnostics.AddError("Unable to parse endpoint url", err.Error())
 		return
 	}
+	// self hosted servers don't have a region, in that case no region checks are done
+	if urlRegion := serverUrlRegion(url); urlRegion != "" && region != "" && urlRegion != region {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("server_url"),
+			"Region Mismatch",
+			fmt.Sprintf("The server url %s is in the %q region, but the provider is configured for the %q region.", server_url, urlRegion, region),
+		)
+		return
+	} else if urlRegion == "" {
+		region = ""
+	}
+
+	if _, debug := os.LookupEnv("SVIX_DEBUG"); debug {
+		resp.Diagnostics.AddWarning(
+			"SVIX_DEBUG Is Deprecated",
+			"SVIX_DEBUG no longer has any effect, the requests sent to the Svix API are logged with "+
+				"TF_LOG_PROVIDER=DEBUG (or TRACE to include the request and response bodies).",
+		)
+	}
 
-	_, debug := os.LookupEnv("SVIX_DEBUG")
+	retryCfg := defaultRetryConfig
+	if !data.MaxRetries.IsNull() {
+		retryCfg.maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	durations := []struct {
+		attr  string
+		value timetypes.GoDuration
+		dest  *time.Duration
+	}{
+		{"retry_min_backoff", data.RetryMinBackoff, &retryCfg.minBackoff},
+		{"retry_max_backoff", data.RetryMaxBackoff, &retryCfg.maxBackoff},
+		{"request_timeout", data.RequestTimeout, &retryCfg.requestTimeout},
+	}
+	for _, d := range durations {
+		if d.value.IsNull() || d.value.IsUnknown() {
+			continue
+		}
+		v, diags := d.value.ValueGoDuration()
+		resp.Diagnostics.Append(diags...)
+		if v < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root(d.attr), "Invalid Duration", fmt.Sprintf("`%s` must not be negative, got: %s", d.attr, d.value.ValueString()))
+		}
+		*d.dest = v
+	}
+	if retryCfg.minBackoff > retryCfg.maxBackoff {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Invalid Retry Backoff",
+			fmt.Sprintf("`retry_min_backoff` (%s) must not be greater than `retry_max_backoff` (%s)", retryCfg.minBackoff, retryCfg.maxBackoff),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	appState := appState{
-		token:     token,
-		serverUrl: *url,
-		debug:     debug,
+	appState := &appState{
+		tokens:            tokens,
+		environmentTokens: environmentTokens,
+		serverUrl:         *url,
+		region:            region,
+		environmentId:     environment_id,
+		httpClient:        newHttpClient(retryCfg),
+		clients:           map[clientKey]any{},
 	}
 
 	resp.DataSourceData = appState
 	resp.ResourceData = appState
+	resp.EphemeralResourceData = appState
 
 }
 
+// get the organization api token from the provider configuration, falling back to the environment variables.
+// Returns nil if no token is configured
+func configureTokenSource(ctx context.Context, data SvixProviderModel) (*tokenSource, error) {
+	var tokens *tokenSource
+	switch {
+	case data.Token.ValueString() != "":
+		tokens = staticTokenSource(data.Token.ValueString())
+	case data.TokenFile.ValueString() != "":
+		return fileTokenSource(data.TokenFile.ValueString())
+	case !data.TokenCommand.IsNull() && !data.TokenCommand.IsUnknown():
+		var command []string
+		diags := data.TokenCommand.ElementsAs(ctx, &command, false)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to read token_command")
+		}
+		tokens = commandTokenSource(command)
+	case os.Getenv("SVIX_TOKEN") != "":
+		tokens = staticTokenSource(os.Getenv("SVIX_TOKEN"))
+	case os.Getenv("SVIX_TOKEN_FILE") != "":
+		return fileTokenSource(os.Getenv("SVIX_TOKEN_FILE"))
+	default:
+		return nil, nil
+	}
+
+	// run `token_command` now, so a failing command is reported before any resource is touched
+	if _, err := tokens.Token(ctx); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
 func (p *SvixProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewApiTokenResource,
+		NewApplicationResource,
+		NewEndpointResource,
+		NewEndpointHeadersResource,
+		NewEndpointTransformationResource,
+		NewEndpointMtlsResource,
+		NewEndpointOauthResource,
 		NewEnvironmentResource,
 		NewEnvironmentSettingsResource,
 		NewEventTypeOpenapiImportResource,
@@ -117,15 +344,30 @@ func (p *SvixProvider) Resources(ctx context.Context) []func() resource.Resource
 	}
 }
 func (p *SvixProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return nil
+	return []func() ephemeral.EphemeralResource{
+		NewAppPortalAccessEphemeralResource,
+		NewEndpointSecretEphemeralResource,
+		NewIngestEndpointSecretEphemeralResource,
+		NewOperationalWebhooksEndpointSecretEphemeralResource,
+	}
 }
 
 func (p *SvixProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewEnvironmentDataSource,
+		NewEnvironmentsDataSource,
+		NewEventTypeDataSource,
+		NewEventTypesDataSource,
+		NewSvixIngestSourceDataSource,
+		NewSvixIngestSourcesDataSource,
+	}
 }
 
 func (p *SvixProvider) Functions(ctx context.Context) []func() function.Function {
-	return nil
+	return []func() function.Function{
+		NewSignPayloadFunction,
+		NewVerifyPayloadFunction,
+	}
 }
 
 func New() func() provider.Provider {
@@ -135,30 +377,122 @@ func New() func() provider.Provider {
 }
 
 type appState struct {
-	token     string
+	// the organization api token, nil if only `environment_tokens` are configured
+	tokens *tokenSource
+	// environment scoped api tokens, keyed by environment id
+	environmentTokens map[string]string
+
 	serverUrl url.URL
-	debug     bool
+	// the region of `serverUrl`, empty for self hosted servers
+	region string
+	// the default environment id, empty if not configured
+	environmentId string
+	// shared by all the svix clients, handles retries and timeouts
+	httpClient *http.Client
+
+	// regions of the environments, cached since every resource in an environment checks it
+	envRegionsMu sync.Mutex
+	envRegions   map[string]*envRegionLookup
+
+	// clients are cached so connections are reused across resources
+	clientsMu sync.Mutex
+	clients   map[clientKey]any
+}
+
+// key of the client pool, the same token can be used with different environments and with both clients
+type clientKey struct {
+	token    string
+	envId    string
+	internal bool
+}
+
+// get a client from the pool, creating it if needed
+func cachedClient[T any](s *appState, key clientKey, create func() (T, error)) (T, error) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	if c, ok := s.clients[key].(T); ok {
+		return c, nil
+	}
+	c, err := create()
+	if err != nil {
+		return c, err
+	}
+	if s.clients == nil {
+		s.clients = map[clientKey]any{}
+	}
+	s.clients[key] = c
+	return c, nil
 }
 
 var userAgentSuffix = fmt.Sprintf("tf-provider-v%s", Version)
 
+// options shared by all the public svix clients, the sdk retries are disabled since `httpClient` handles them
+func (s *appState) svixOptions() *svix.SvixOptions {
+	return &svix.SvixOptions{
+		ServerUrl:     &s.serverUrl,
+		HTTPClient:    s.httpClient,
+		RetrySchedule: &[]time.Duration{},
+	}
+}
+
+// get the bearer token of an environment, an empty envId is used for the organization level token.
+// `ctx` cancels `token_command` if it has to be run, e.g. when the run is interrupted.
+//
+// An environment scoped token from `environment_tokens` is used as is, otherwise the envId is suffixed on the organization token
+func (s *appState) bearerToken(ctx context.Context, envId string) (string, error) {
+	if token, ok := s.environmentTokens[envId]; ok && envId != "" {
+		return token, nil
+	}
+	if s.tokens == nil {
+		if envId == "" {
+			return "", fmt.Errorf("an organization api token is required, only environment_tokens are configured")
+		}
+		return "", fmt.Errorf("environment %s is not in environment_tokens, and no organization api token is configured", envId)
+	}
+	token, err := s.tokens.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	if envId == "" {
+		return token, nil
+	}
+	return fmt.Sprintf("%s|%s", token, envId), nil
+}
+
 // get the default client without an envId suffixed
-func (s *appState) DefaultSvixClient() (*svix.Svix, error) {
-	svx, err := svix.New(s.token, &svix.SvixOptions{ServerUrl: &s.serverUrl, Debug: s.debug})
+func (s *appState) DefaultSvixClient(ctx context.Context) (*svix.Svix, error) {
+	return s.ClientWithEnvId(ctx, "")
+}
+
+// get a svix client authenticated for an environment
+func (s *appState) ClientWithEnvId(ctx context.Context, envId string) (*svix.Svix, error) {
+	bearerToken, err := s.bearerToken(ctx, envId)
 	if err != nil {
 		return nil, err
 	}
-	err = svx.SetUserAgentSuffix(userAgentSuffix)
+	return cachedClient(s, clientKey{token: bearerToken, envId: envId}, func() (*svix.Svix, error) {
+		return s.newSvixClient(bearerToken)
+	})
+}
+
+// get an internal svix client authenticated for an environment
+func (s *appState) InternalClientWithEnvId(ctx context.Context, envId string) (*svix_internal.InternalSvix, error) {
+	bearerToken, err := s.bearerToken(ctx, envId)
 	if err != nil {
 		return nil, err
 	}
-	return svx, nil
+	return cachedClient(s, clientKey{token: bearerToken, envId: envId, internal: true}, func() (*svix_internal.InternalSvix, error) {
+		return s.newInternalSvixClient(bearerToken)
+	})
+}
+
+// get the default internal svix client without an envId suffixed
+func (s *appState) InternalDefaultSvixClient(ctx context.Context) (*svix_internal.InternalSvix, error) {
+	return s.InternalClientWithEnvId(ctx, "")
 }
 
-// create a new svix client with the envId suffixed on the token
-func (s *appState) ClientWithEnvId(envId string) (*svix.Svix, error) {
-	bearerToken := fmt.Sprintf("%s|%s", s.token, envId)
-	svx, err := svix.New(bearerToken, &svix.SvixOptions{ServerUrl: &s.serverUrl, Debug: s.debug})
+func (s *appState) newSvixClient(bearerToken string) (*svix.Svix, error) {
+	svx, err := svix.New(bearerToken, s.svixOptions())
 	if err != nil {
 		return nil, err
 	}
@@ -167,23 +501,14 @@ func (s *appState) ClientWithEnvId(envId string) (*svix.Svix, error) {
 		return nil, err
 	}
 	return svx, nil
-
 }
 
-// create a new internal svix client with the envId suffixed on the token
-func (s *appState) InternalClientWithEnvId(envId string) (*svix_internal.InternalSvix, error) {
-	bearerToken := fmt.Sprintf("%s|%s", s.token, envId)
-	svx, err := svix_internal.New(bearerToken, &s.serverUrl, s.debug, &userAgentSuffix)
+func (s *appState) newInternalSvixClient(bearerToken string) (*svix_internal.InternalSvix, error) {
+	svx, err := svix_internal.New(bearerToken, &s.serverUrl, false, &userAgentSuffix)
 	if err != nil {
 		return nil, err
 	}
-	return svx, nil
-
-}
-
-// get the default internal svix client without an envId suffixed
-func (s *appState) InternalDefaultSvixClient() (*svix_internal.InternalSvix, error) {
-	svx, err := svix_internal.New(s.token, &s.serverUrl, s.debug, &userAgentSuffix)
+	err = setInternalHttpClient(svx, s.httpClient)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/svix/svix-webhooks/go/models"
+)
+
+// api base url of every svix region, same as the ones used by the svix sdk
+var regionServerUrls = map[string]string{
+	"eu": "https://api.eu.svix.com",
+	"us": "https://api.us.svix.com",
+	"ca": "https://api.ca.svix.com",
+	"au": "https://api.au.svix.com",
+	"in": "https://api.in.svix.com",
+}
+
+func regionNames() []string {
+	var names []string
+	for name := range regionServerUrls {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// svix tokens end with the region they were issued in (e.g. `sk_xxx.eu`), returns an empty string if there is no region suffix
+func tokenRegion(token string) string {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return ""
+	}
+	region := token[i+1:]
+	if _, ok := regionServerUrls[region]; !ok {
+		return ""
+	}
+	return region
+}
+
+// returns the region of a svix api url, or an empty string for a self hosted server
+func serverUrlRegion(u *url.URL) string {
+	for region, serverUrl := range regionServerUrls {
+		regionUrl, _ := url.Parse(serverUrl)
+		if strings.EqualFold(u.Hostname(), regionUrl.Hostname()) {
+			return region
+		}
+	}
+	return ""
+}
+
+// get the region of the configured tokens, an error is returned if the tokens were issued in different regions
+func configuredTokensRegion(ctx context.Context, tokens *tokenSource, environmentTokens map[string]string) (string, error) {
+	var allTokens []string
+	if tokens != nil {
+		token, err := tokens.Token(ctx)
+		if err != nil {
+			return "", err
+		}
+		allTokens = append(allTokens, token)
+	}
+	envIds := make([]string, 0, len(environmentTokens))
+	for envId := range environmentTokens {
+		envIds = append(envIds, envId)
+	}
+	slices.Sort(envIds)
+	for _, envId := range envIds {
+		allTokens = append(allTokens, environmentTokens[envId])
+	}
+
+	region := ""
+	for _, token := range allTokens {
+		r := tokenRegion(token)
+		if r == "" {
+			continue
+		}
+		if region != "" && r != region {
+			return "", fmt.Errorf("the configured tokens were issued in different regions (%s and %s), use a provider alias per region", region, r)
+		}
+		region = r
+	}
+	return region, nil
+}
+
+// make sure an environment is in the provider's region. Requests to an environment in another region
+// fail with a 404, so this reports a clear error during the plan instead.
+//
+// Errors while looking up the environment are ignored, the requests to the environment will report them
+func (s *appState) checkEnvironmentRegion(ctx context.Context, d *diag.Diagnostics, envId string) {
+	if s == nil || s.region == "" || envId == "" {
+		return
+	}
+
+	envRegion := s.environmentRegion(ctx, envId)
+	if envRegion == "" || envRegion == string(models.ENVIRONMENTREGION_SELF_HOSTED) || envRegion == s.region {
+		return
+	}
+	d.AddAttributeError(
+		rp("environment_id"),
+		"Environment In A Different Region",
+		fmt.Sprintf("Environment %s is in the %q region, but the provider is configured for the %q region (%s). "+
+			"Use a provider alias with `region = %q` for the resources of this environment.",
+			envId, envRegion, s.region, s.serverUrl.String(), envRegion),
+	)
+}
+
+// the region lookup of an environment, it is only done once per environment
+type envRegionLookup struct {
+	once   sync.Once
+	region string
+}
+
+// get the cached region of an environment, looking it up if needed.
+//
+// The lock is only held to get the lookup of the environment, so lookups of different environments run in parallel
+// and the resources of an environment wait for its first lookup
+func (s *appState) environmentRegion(ctx context.Context, envId string) string {
+	s.envRegionsMu.Lock()
+	lookup, ok := s.envRegions[envId]
+	if !ok {
+		if s.envRegions == nil {
+			s.envRegions = map[string]*envRegionLookup{}
+		}
+		lookup = &envRegionLookup{}
+		s.envRegions[envId] = lookup
+	}
+	s.envRegionsMu.Unlock()
+
+	lookup.once.Do(func() {
+		lookup.region = s.lookupEnvironmentRegion(ctx, envId)
+	})
+	return lookup.region
+}
+
+func (s *appState) lookupEnvironmentRegion(ctx context.Context, envId string) string {
+	if s.tokens == nil {
+		return ""
+	}
+	svx, err := s.InternalDefaultSvixClient(ctx)
+	if err != nil {
+		return ""
+	}
+	env, err := svx.Management.Environment.Get(ctx, envId)
+	if err != nil {
+		return ""
+	}
+	return string(env.Region)
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	svix "github.com/svix/svix-webhooks/go"
+)
+
+var _ function.Function = &SignPayloadFunction{}
+
+const webhookSecretPrefix = "whsec_"
+
+func NewSignPayloadFunction() function.Function {
+	return &SignPayloadFunction{}
+}
+
+type SignPayloadFunction struct{}
+
+// parse a `whsec_` prefixed endpoint secret, the svix sdk also accepts secrets without the prefix
+// but the endpoint secrets returned by the api always have it
+func webhookFromSecret(secret string) (*svix.Webhook, error) {
+	if !strings.HasPrefix(secret, webhookSecretPrefix) {
+		return nil, fmt.Errorf("the secret must start with `%s`", webhookSecretPrefix)
+	}
+	wh, err := svix.NewWebhook(secret)
+	if err != nil {
+		return nil, fmt.Errorf("the secret must be base64 encoded after the `%s` prefix: %s", webhookSecretPrefix, err.Error())
+	}
+	return wh, nil
+}
+
+func (f *SignPayloadFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sign_payload"
+}
+
+func (f *SignPayloadFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Sign a webhook payload",
+		MarkdownDescription: "Sign a webhook payload using the [Standard Webhooks](https://www.standardwebhooks.com/) scheme used by Svix. " +
+			"Returns the value of the `svix-signature` header (e.g. `v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "secret",
+				Description: "The endpoint's signing secret, prefixed with `whsec_`",
+			},
+			function.StringParameter{
+				Name:        "msg_id",
+				Description: "The message id, sent in the `svix-id` header",
+			},
+			function.Int64Parameter{
+				Name:        "timestamp",
+				Description: "The unix timestamp (in seconds) of the message, sent in the `svix-timestamp` header",
+			},
+			function.StringParameter{
+				Name:        "body",
+				Description: "The raw body of the webhook",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SignPayloadFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secret, msgId, body string
+	var timestamp int64
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &secret, &msgId, &timestamp, &body))
+	if resp.Error != nil {
+		return
+	}
+
+	wh, err := webhookFromSecret(secret)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	signature, err := wh.Sign(msgId, time.Unix(timestamp, 0), []byte(body))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, signature))
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// how long `token_command` may run before it is killed
+	tokenCommandTimeout = 30 * time.Second
+	// tokens returned by `token_command` are refreshed this long before they expire
+	tokenRefreshMargin = time.Minute
+)
+
+// provides the organization api token, either a static token (`token` or `token_file`)
+// or a token returned by `token_command`, which is cached until it expires
+type tokenSource struct {
+	command []string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time // zero if the token doesn't expire
+}
+
+func staticTokenSource(token string) *tokenSource {
+	return &tokenSource{token: token}
+}
+
+// read the token from a file, surrounding whitespace is ignored
+func fileTokenSource(file string) (*tokenSource, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the token file: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return nil, fmt.Errorf("the token file %q is empty", file)
+	}
+	return staticTokenSource(token), nil
+}
+
+func commandTokenSource(command []string) *tokenSource {
+	return &tokenSource{command: command}
+}
+
+// get the current token, running `token_command` if there is no token yet or it is about to expire
+func (t *tokenSource) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && (t.expiresAt.IsZero() || time.Now().Add(tokenRefreshMargin).Before(t.expiresAt)) {
+		return t.token, nil
+	}
+	if len(t.command) == 0 {
+		return t.token, nil
+	}
+
+	token, expiresAt, err := runTokenCommand(ctx, t.command)
+	if err != nil {
+		return "", err
+	}
+	t.token = token
+	t.expiresAt = expiresAt
+	return t.token, nil
+}
+
+// output of `token_command`, same shape as the `ExecCredential` printed by kubectl credential plugins
+type tokenCommandOutput struct {
+	Status struct {
+		Token               string     `json:"token"`
+		ExpirationTimestamp *time.Time `json:"expirationTimestamp,omitempty"`
+	} `json:"status"`
+}
+
+func runTokenCommand(ctx context.Context, command []string) (string, time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", time.Time{}, fmt.Errorf("token_command %q failed: %w\n\nstderr: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var out tokenCommandOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return "", time.Time{}, fmt.Errorf("unable to parse the output of token_command %q: %w", command[0], err)
+	}
+	if out.Status.Token == "" {
+		return "", time.Time{}, fmt.Errorf("the output of token_command %q has no `status.token`", command[0])
+	}
+	var expiresAt time.Time
+	if out.Status.ExpirationTimestamp != nil {
+		expiresAt = *out.Status.ExpirationTimestamp
+	}
+	return out.Status.Token, expiresAt, nil
+}
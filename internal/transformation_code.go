@@ -0,0 +1,294 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = (*transformationCodeType)(nil)
+	_ basetypes.StringValuableWithSemanticEquals = (*transformationCode)(nil)
+	_ xattr.ValidateableAttribute                = (*transformationCode)(nil)
+)
+
+// the transformation code must define a `handler` function, which svix calls with the webhook to transform
+var transformationHandlerRegex = regexp.MustCompile(`\bfunction\s+handler\s*\(|\b(const|let|var)\s+handler\s*=`)
+
+// javascript code of an endpoint transformation.
+//
+// Code that only differs in whitespace (line endings, trailing whitespace and surrounding blank lines) is
+// considered equal, so code loaded with `file()` doesn't show a diff when it is checked out on another OS
+type transformationCodeType struct {
+	basetypes.StringType
+}
+
+func (t transformationCodeType) String() string {
+	return "transformationCodeType"
+}
+
+func (t transformationCodeType) ValueType(ctx context.Context) attr.Value {
+	return transformationCode{}
+}
+
+func (t transformationCodeType) Equal(o attr.Type) bool {
+	other, ok := o.(transformationCodeType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t transformationCodeType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return transformationCode{StringValue: in}, nil
+}
+
+func (t transformationCodeType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+type transformationCode struct {
+	basetypes.StringValue
+}
+
+func newTransformationCodeValue(code string) transformationCode {
+	return transformationCode{StringValue: basetypes.NewStringValue(code)}
+}
+
+func (v transformationCode) Type(_ context.Context) attr.Type {
+	return transformationCodeType{}
+}
+
+func (v transformationCode) Equal(o attr.Value) bool {
+	other, ok := o.(transformationCode)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v transformationCode) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(transformationCode)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	return normalizeTransformationCode(v.ValueString()) == normalizeTransformationCode(newValue.ValueString()), diags
+}
+
+// check the syntax of the code during the plan, so broken transformations are never sent to svix
+func (v transformationCode) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if err := checkTransformationSyntax(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Transformation Code", err.Error())
+	}
+}
+
+// use `\n` line endings and remove trailing whitespace and leading/trailing blank lines
+func normalizeTransformationCode(code string) string {
+	lines := strings.Split(strings.ReplaceAll(code, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// keywords after which a `/` starts a regular expression rather than a division
+var jsKeywordsBeforeExpression = map[string]bool{
+	"return": true, "typeof": true, "case": true, "do": true, "else": true, "in": true, "of": true, "new": true,
+	"delete": true, "void": true, "throw": true, "yield": true, "await": true, "instanceof": true,
+}
+
+// whether the last token ends an operand (an identifier, literal or closed bracket), after which `++` and `--` are postfix operators
+func isOperandEnd(prev rune, prevWord string) bool {
+	switch prev {
+	case 'a':
+		return !jsKeywordsBeforeExpression[prevWord]
+	case ')', ']', '"', '\'', '`':
+		return true
+	}
+	return false
+}
+
+// a lightweight syntax check of the transformation: strings, template literals, comments and regular expressions
+// must be terminated, brackets must be balanced and a `handler` function must be defined.
+//
+// This doesn't replace a javascript parser, it catches the common mistakes (e.g. a missing `}`) before they are applied
+func checkTransformationSyntax(code string) error {
+	type opener struct {
+		char     rune
+		line     int
+		template bool // `${` of a template literal
+	}
+	var stack []opener
+	closers := map[rune]rune{')': '(', ']': '[', '}': '{'}
+
+	rs := []rune(code)
+	line := 1
+	// the last significant token, to tell regular expressions and divisions apart
+	var prev rune
+	var prevWord string
+	// line of the template literal being scanned, 0 when scanning code
+	templateLine := 0
+
+	next := func(i int) rune {
+		if i+1 < len(rs) {
+			return rs[i+1]
+		}
+		return 0
+	}
+
+	for i := 0; i < len(rs); i++ {
+		c := rs[i]
+
+		if templateLine != 0 {
+			switch {
+			case c == '\\':
+				i++
+				if i < len(rs) && rs[i] == '\n' {
+					line++
+				}
+			case c == '`':
+				templateLine = 0
+				prev, prevWord = c, ""
+			case c == '$' && next(i) == '{':
+				stack = append(stack, opener{char: '{', line: line, template: true})
+				templateLine = 0
+				prev, prevWord = '{', ""
+				i++
+			case c == '\n':
+				line++
+			}
+			continue
+		}
+
+		switch {
+		case c == '\n':
+			line++
+		case unicode.IsSpace(c):
+		case c == '/' && next(i) == '/':
+			for i+1 < len(rs) && rs[i+1] != '\n' {
+				i++
+			}
+		case c == '/' && next(i) == '*':
+			start := line
+			i += 2
+			for ; i < len(rs) && !(rs[i] == '*' && next(i) == '/'); i++ {
+				if rs[i] == '\n' {
+					line++
+				}
+			}
+			if i >= len(rs) {
+				return fmt.Errorf("line %d: unterminated comment", start)
+			}
+			i++
+		case c == '"' || c == '\'':
+			for i++; i < len(rs) && rs[i] != c; i++ {
+				if rs[i] == '\\' {
+					i++
+				} else if rs[i] == '\n' {
+					break
+				}
+			}
+			if i >= len(rs) || rs[i] != c {
+				return fmt.Errorf("line %d: unterminated string", line)
+			}
+			prev, prevWord = c, ""
+		case c == '`':
+			templateLine = line
+		case (c == '+' || c == '-') && next(i) == c:
+			i++
+			if isOperandEnd(prev, prevWord) {
+				// postfix `i++`, the expression continues like after an operand, so a `/` that follows is a division
+				prev, prevWord = ')', ""
+			} else {
+				prev, prevWord = c, ""
+			}
+		case c == '/' && (prev == 0 || strings.ContainsRune("(,=:[!&|?{};+-*%<>~^", prev) || jsKeywordsBeforeExpression[prevWord]):
+			inClass := false
+			for i++; i < len(rs) && (inClass || rs[i] != '/'); i++ {
+				switch rs[i] {
+				case '\\':
+					i++
+				case '[':
+					inClass = true
+				case ']':
+					inClass = false
+				}
+				if i < len(rs) && rs[i] == '\n' {
+					break
+				}
+			}
+			if i >= len(rs) || rs[i] != '/' {
+				return fmt.Errorf("line %d: unterminated regular expression", line)
+			}
+			// flags
+			for i+1 < len(rs) && unicode.IsLetter(rs[i+1]) {
+				i++
+			}
+			prev, prevWord = '/', ""
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, opener{char: c, line: line})
+			prev, prevWord = c, ""
+		case closers[c] != 0:
+			if len(stack) == 0 {
+				return fmt.Errorf("line %d: unexpected `%c`", line, c)
+			}
+			top := stack[len(stack)-1]
+			if top.char != closers[c] {
+				return fmt.Errorf("line %d: unexpected `%c`, the `%c` opened on line %d isn't closed", line, c, top.char, top.line)
+			}
+			stack = stack[:len(stack)-1]
+			if top.template {
+				templateLine = top.line
+			}
+			prev, prevWord = c, ""
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '$':
+			start := i
+			for i+1 < len(rs) && (unicode.IsLetter(rs[i+1]) || unicode.IsDigit(rs[i+1]) || rs[i+1] == '_' || rs[i+1] == '$') {
+				i++
+			}
+			prev, prevWord = 'a', string(rs[start:i+1])
+		default:
+			prev, prevWord = c, ""
+		}
+	}
+
+	if templateLine != 0 {
+		return fmt.Errorf("line %d: unterminated template literal", templateLine)
+	}
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return fmt.Errorf("line %d: the `%c` isn't closed", top.line, top.char)
+	}
+	if !transformationHandlerRegex.MatchString(code) {
+		return fmt.Errorf("the code must define a `handler` function, e.g. `function handler(webhook) { return webhook; }`")
+	}
+	return nil
+}
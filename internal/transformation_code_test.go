@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTransformationCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{name: "unchanged", code: "function handler(w) {\n  return w;\n}", want: "function handler(w) {\n  return w;\n}"},
+		{name: "crlf line endings", code: "function handler(w) {\r\n  return w;\r\n}\r\n", want: "function handler(w) {\n  return w;\n}"},
+		{name: "trailing whitespace", code: "function handler(w) { \t\n  return w;  \n}", want: "function handler(w) {\n  return w;\n}"},
+		{name: "surrounding blank lines", code: "\n\n  \nfunction handler(w) {\n  return w;\n}\n\n \n", want: "function handler(w) {\n  return w;\n}"},
+		{name: "inner blank lines and indentation are kept", code: "  const a = 1;\n\n\n  function handler(w) { return w; }", want: "  const a = 1;\n\n\n  function handler(w) { return w; }"},
+		{name: "empty", code: " \r\n\n", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeTransformationCode(tt.code); got != tt.want {
+				t.Errorf("normalizeTransformationCode(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformationCodeSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	a := newTransformationCodeValue("function handler(w) {\r\n  return w;\r\n}\r\n")
+	if eq, _ := a.StringSemanticEquals(ctx, newTransformationCodeValue("function handler(w) {\n  return w;\n}")); !eq {
+		t.Error("code that only differs in whitespace should be equal")
+	}
+	if eq, _ := a.StringSemanticEquals(ctx, newTransformationCodeValue("function handler(w) {\n  return null;\n}")); eq {
+		t.Error("different code shouldn't be equal")
+	}
+}
+
+func TestCheckTransformationSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr string
+	}{
+		{name: "function handler", code: "function handler(webhook) {\n  return webhook;\n}"},
+		{name: "arrow function handler", code: "const handler = (webhook) => ({ ...webhook, method: 'PUT' });"},
+		{name: "division", code: "function handler(w) { const n = w.a / 2 / w.b; return w; }"},
+		{name: "division after postfix increment", code: "function handler(w) { let i = 0; const n = i++ / 2; return w; }"},
+		{name: "division after postfix decrement", code: "function handler(w) { let i = 4; const n = i-- / 2; return w; }"},
+		{name: "division after a member postfix increment", code: "function handler(w) { w.payload.count++ / 2; w.list[0]-- / 2; return w; }"},
+		{name: "regular expression after prefix increment", code: "function handler(w) { let i = 0; const ok = ++i && /a/.test(w.url); return w; }"},
+		{name: "regular expression", code: "function handler(w) { w.url = w.url.replace(/\\/+$/g, ''); return w; }"},
+		{name: "regular expression with brackets", code: "function handler(w) { const re = /[/(]{1,2}/; return w; }"},
+		{name: "regular expression after return", code: "function handler(w) { return /^https:/.test(w.url) ? w : null; }"},
+		{name: "brackets in strings and comments", code: "// handler ( {\nfunction handler(w) {\n  /* ] */ w.a = \"(\" + '[' ;\n  return w;\n}"},
+		{name: "template literal", code: "function handler(w) { w.url = `${w.url}/${w.payload.id}?q=${`${w.a}`}`; return w; }"},
+		{name: "escaped quotes", code: "function handler(w) { w.a = 'it\\'s'; w.b = \"\\\"\"; return w; }"},
+
+		{name: "missing handler", code: "function transform(w) { return w; }", wantErr: "must define a `handler` function"},
+		{name: "unclosed brace", code: "function handler(w) {\n  if (w.a) {\n    return w;\n}", wantErr: "line 1: the `{` isn't closed"},
+		{name: "unexpected closer", code: "function handler(w) { return w; }}", wantErr: "line 1: unexpected `}`"},
+		{name: "mismatched brackets", code: "function handler(w) {\n  return [w);\n}", wantErr: "line 2: unexpected `)`, the `[` opened on line 2 isn't closed"},
+		{name: "unterminated string", code: "function handler(w) {\n  w.a = 'abc;\n  return w;\n}", wantErr: "line 2: unterminated string"},
+		{name: "unterminated template literal", code: "function handler(w) {\n  w.a = `abc;\n  return w;\n}", wantErr: "line 2: unterminated template literal"},
+		{name: "unterminated comment", code: "function handler(w) { return w; }\n/* abc", wantErr: "line 2: unterminated comment"},
+		{name: "unterminated regular expression", code: "function handler(w) {\n  const re = /abc;\n  return w;\n}", wantErr: "line 2: unterminated regular expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTransformationSyntax(tt.code)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"math/rand/v2"
 	"net/http"
 	"regexp"
+	"slices"
+	"strings"
 	"sync"
+	"unicode"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -48,10 +50,6 @@ func randStr32() *string {
 
 }
 
-func Spw(v any) {
-	log.Println(spew.Sdump(v))
-}
-
 func ptr[T any](value T) *T {
 	return &value
 }
@@ -106,6 +104,16 @@ func boolOrNil(v types.Bool) *bool {
 	return v.ValueBoolPointer()
 }
 
+// convert a []string to a types.List, an empty slice is converted to a null list
+func stringListOrNull(ctx context.Context, d *diag.Diagnostics, v []string) types.List {
+	if len(v) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	ret, diags := types.ListValueFrom(ctx, types.StringType, v)
+	d.Append(diags...)
+	return ret
+}
+
 // wrapper function around `resp.Diagnostics.Append(resp.State.SetAttribute())` for *CreateResponse
 func setCreateState(ctx context.Context, resp *resource.CreateResponse, path path.Path, val any) {
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path, val)...)
@@ -125,13 +133,217 @@ func rp(rootPath string) path.Path {
 	return path.Root(rootPath)
 }
 
+// import a resource from a `/` separated identifier, each part of the identifier is saved to the matching attribute
+//
+// for example `importStateFromId(ctx, req, resp, "environment_id", "name")` accepts `env_xxx/user.created`
+func importStateFromId(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, attrs ...string) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != len(attrs) || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: `%s`. Got: %q", strings.Join(attrs, "/"), req.ID),
+		)
+		return
+	}
+	for i, attr := range attrs {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, rp(attr), parts[i])...)
+	}
+}
+
+// add an error diagnostic for an error returned by the svix api.
+//
+// Validation errors are added on the attribute they refer to, and common status codes get a hint on how to fix them
 func logSvixError(d *diag.Diagnostics, err error, msg string) {
+	status, body, ok := svixErrorStatusAndBody(err)
+	if !ok {
+		d.AddError(msg, err.Error())
+		return
+	}
+
+	fmtError := fmt.Sprintf("status code: %d %s\n\nbody: %s", status, http.StatusText(status), string(body))
+	if status == http.StatusUnprocessableEntity && addValidationErrors(d, body, msg) {
+		return
+	}
+	if hint := svixErrorHint(status); hint != "" {
+		fmtError = hint + "\n\n" + fmtError
+	}
+	d.AddError(msg, fmtError)
+}
+
+// returns the status code and body of a `*svix.Error` or a `*managementApiError`
+func svixErrorStatusAndBody(err error) (int, []byte, bool) {
 	var svixError *svix.Error
 	if errors.As(err, &svixError) {
-		fmtError := fmt.Sprintf("status code: %d %s\n\nbody: %s", svixError.Status(), http.StatusText(svixError.Status()), string(svixError.Body()))
-		d.AddError(msg, fmtError)
-	} else {
-		d.AddError(msg, err.Error())
+		return svixError.Status(), svixError.Body(), true
+	}
+	var managementError *managementApiError
+	if errors.As(err, &managementError) {
+		return managementError.status, managementError.body, true
+	}
+	return 0, nil, false
+}
+
+func svixErrorHint(status int) string {
+	switch status {
+	case http.StatusUnauthorized:
+		return "The API token was rejected. Check that the token is valid and was issued for the region of the server url " +
+			"(the token ends with its region, e.g. `.eu`)."
+	case http.StatusForbidden:
+		return "The API token is not allowed to do this. Check that `environment_id` is an environment of the token's organization " +
+			"(the environment id is suffixed on the token), or that the environment's plan includes this feature " +
+			"(some settings require the Enterprise plan)."
+	case http.StatusConflict:
+		return "An object with the same uid or name already exists. If it should be managed by terraform, " +
+			"import it with `terraform import` (or an `import` block) instead of creating it."
+	case http.StatusTooManyRequests:
+		return "The request was rate limited, and still failed after retrying. Increase `max_retries` or `retry_max_backoff` " +
+			"in the provider configuration, or lower terraform's `-parallelism`."
+	}
+	return ""
+}
+
+// body of a 422 response
+type svixValidationError struct {
+	Detail []struct {
+		Loc []any  `json:"loc"`
+		Msg string `json:"msg"`
+	} `json:"detail"`
+}
+
+// add one attribute error per entry of a validation error, returns false if the body isn't a validation error.
+//
+// The location of each entry is the path of the invalid field in the request body, e.g. `["body", "filterTypes", 0]`,
+// which is converted to the matching schema path (`filter_types[0]`). Errors outside of the body are added without a path
+func addValidationErrors(d *diag.Diagnostics, body []byte, msg string) bool {
+	var validationError svixValidationError
+	if err := json.Unmarshal(body, &validationError); err != nil || len(validationError.Detail) == 0 {
+		return false
+	}
+	for _, entry := range validationError.Detail {
+		attrPath, ok := validationErrorPath(entry.Loc)
+		if !ok {
+			d.AddError(msg, fmt.Sprintf("%s: %s", formatValidationErrorLoc(entry.Loc), entry.Msg))
+			continue
+		}
+		d.AddAttributeError(attrPath, msg, fmt.Sprintf("%s: %s", attrPath.String(), entry.Msg))
+	}
+	return true
+}
+
+func validationErrorPath(loc []any) (path.Path, bool) {
+	if len(loc) < 2 || loc[0] != "body" {
+		return path.Empty(), false
+	}
+	name, ok := loc[1].(string)
+	if !ok {
+		return path.Empty(), false
 	}
+	attrPath := rp(camelToSnakeCase(name))
+	for _, step := range loc[2:] {
+		switch step := step.(type) {
+		case string:
+			attrPath = attrPath.AtName(camelToSnakeCase(step))
+		case float64:
+			attrPath = attrPath.AtListIndex(int(step))
+		}
+	}
+	return attrPath, true
+}
+
+func formatValidationErrorLoc(loc []any) string {
+	parts := make([]string, 0, len(loc))
+	for _, step := range loc {
+		parts = append(parts, fmt.Sprint(step))
+	}
+	return strings.Join(parts, ".")
+}
+
+// convert the name of a field in the api (`filterTypes`) to the name of the matching attribute (`filter_types`)
+func camelToSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// returns true if err is a `*svix.Error` (or a `*managementApiError`) with a 404 status code
+func isNotFoundError(err error) bool {
+	var svixError *svix.Error
+	if errors.As(err, &svixError) {
+		return svixError.Status() == http.StatusNotFound
+	}
+	var managementError *managementApiError
+	return errors.As(err, &managementError) && managementError.status == http.StatusNotFound
+}
 
+// like `logSvixError`, but if the object was deleted outside of terraform (404), it is removed from the state
+// instead of failing, so terraform can plan to recreate it
+func logSvixReadError(ctx context.Context, resp *resource.ReadResponse, err error, msg string) {
+	if isNotFoundError(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	logSvixError(&resp.Diagnostics, err, msg)
+}
+
+// fill in the provider's default `environment_id` when the resource doesn't set one.
+// The resolved value is saved in the state, so changing the default plans a replacement
+func (s *appState) planEnvironmentId(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy, or if the provider isn't configured yet
+	if req.Plan.Raw.IsNull() || s == nil {
+		return
+	}
+
+	var configEnvId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, rp("environment_id"), &configEnvId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !configEnvId.IsNull() {
+		if !configEnvId.IsUnknown() {
+			s.checkEnvironmentRegion(ctx, &resp.Diagnostics, configEnvId.ValueString())
+		}
+		return
+	}
+	envId := s.environmentIdOrDefault(ctx, &resp.Diagnostics, configEnvId)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, rp("environment_id"), envId)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var stateEnvId types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, rp("environment_id"), &stateEnvId)...)
+	if stateEnvId.ValueString() != envId {
+		resp.RequiresReplace.Append(rp("environment_id"))
+	}
+}
+
+// returns the configured `environment_id`, or the provider's default if it isn't set.
+// An error is added if the environment is in a different region than the provider
+func (s *appState) environmentIdOrDefault(ctx context.Context, d *diag.Diagnostics, envId types.String) string {
+	if !envId.IsNull() {
+		s.checkEnvironmentRegion(ctx, d, envId.ValueString())
+		return envId.ValueString()
+	}
+	if s.environmentId == "" {
+		d.AddAttributeError(
+			rp("environment_id"),
+			"Missing environment_id",
+			"The environment_id attribute is not set, and no default was found in the "+
+				"SVIX_ENVIRONMENT_ID environment variable or provider configuration block environment_id attribute.",
+		)
+		return ""
+	}
+	s.checkEnvironmentRegion(ctx, d, s.environmentId)
+	return s.environmentId
 }
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &VerifyPayloadFunction{}
+
+func NewVerifyPayloadFunction() function.Function {
+	return &VerifyPayloadFunction{}
+}
+
+type VerifyPayloadFunction struct{}
+
+func (f *VerifyPayloadFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "verify_payload"
+}
+
+func (f *VerifyPayloadFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Verify a webhook payload",
+		MarkdownDescription: "Verify the signature of a webhook payload using the [Standard Webhooks](https://www.standardwebhooks.com/) scheme used by Svix. " +
+			"Returns `true` if one of the signatures matches. The timestamp is not checked against the current time, so this can be used with fixtures. " +
+			"Fails if the secret is not a valid `whsec_` key.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "secret",
+				Description: "The endpoint's signing secret, prefixed with `whsec_`",
+			},
+			function.StringParameter{
+				Name:        "msg_id",
+				Description: "The message id, sent in the `svix-id` header",
+			},
+			function.Int64Parameter{
+				Name:        "timestamp",
+				Description: "The unix timestamp (in seconds) of the message, sent in the `svix-timestamp` header",
+			},
+			function.StringParameter{
+				Name:        "body",
+				Description: "The raw body of the webhook",
+			},
+			function.StringParameter{
+				Name:        "signature",
+				Description: "The value of the `svix-signature` header, may contain multiple space separated signatures",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *VerifyPayloadFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secret, msgId, body, signature string
+	var timestamp int64
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &secret, &msgId, &timestamp, &body, &signature))
+	if resp.Error != nil {
+		return
+	}
+
+	wh, err := webhookFromSecret(secret)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	headers := http.Header{}
+	headers.Set("svix-id", msgId)
+	headers.Set("svix-timestamp", strconv.FormatInt(timestamp, 10))
+	headers.Set("svix-signature", signature)
+	err = wh.VerifyIgnoringTimestamp([]byte(body), headers)
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, err == nil))
+}